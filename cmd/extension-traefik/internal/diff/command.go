@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/cmd/extension-traefik/internal/render"
+	extenimagev "github.com/gardener/gardener-extension-shoot-traefik/imagevector"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/actuator"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/operatorconfig"
+)
+
+// flags stores the diff flags as provided from the command-line
+type flags struct {
+	kubeconfig string
+	file       string
+	namespace  string
+	name       string
+	configFile string
+}
+
+// New creates a new [cli.Command] for comparing the resources, which the
+// extension renders for a shoot, with its deployed ManagedResource.
+func New() *cli.Command {
+	flags := flags{}
+
+	cmd := &cli.Command{
+		Name:  "diff",
+		Usage: "show the changes of the deployed resources of a shoot after an upgrade of the extension",
+		Description: "Renders the resources of the shoot ManagedResource for the given Extension and compares " +
+			"them semantically with the resources of the deployed ManagedResource. The Extension, its Cluster, " +
+			"the ManagedResource and its secrets are read from the seed cluster or from a file with the dumped " +
+			"resources. Added objects are prefixed with a \"+\", removed objects with a \"-\" and modified " +
+			"objects with a \"~\" followed by their changed fields.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "kubeconfig",
+				Usage:       "path to a kubeconfig for the seed cluster",
+				Sources:     cli.EnvVars(clientcmd.RecommendedConfigPathEnvVar),
+				Destination: &flags.kubeconfig,
+			},
+			&cli.StringFlag{
+				Name:        "file",
+				Usage:       "path to a YAML file with the dumped Extension, Cluster, ManagedResource and ManagedResource secrets, used instead of the seed cluster",
+				Destination: &flags.file,
+			},
+			&cli.StringFlag{
+				Name:        "namespace",
+				Usage:       "control plane namespace of the shoot",
+				Required:    true,
+				Destination: &flags.namespace,
+			},
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "name of the Extension",
+				Value:       actuator.ExtensionType,
+				Destination: &flags.name,
+			},
+			&cli.StringFlag{
+				Name:        "config",
+				Usage:       "path to the operator configuration file",
+				Destination: &flags.configFile,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runDiff(ctx, cmd, &flags)
+		},
+	}
+
+	return cmd
+}
+
+// runDiff compares the resources of the Extension given by the [flags] and
+// writes the differences to the writer of the command.
+func runDiff(ctx context.Context, cmd *cli.Command, flags *flags) error {
+	decoder := render.NewDecoder()
+	opts := Options{
+		ImageVector: extenimagev.ImageVector(),
+		Decoder:     decoder,
+	}
+
+	if flags.configFile != "" {
+		var err error
+		opts.OperatorConfig, err = operatorconfig.Load(flags.configFile, decoder)
+		if err != nil {
+			return err
+		}
+	}
+
+	c, err := flags.newClient()
+	if err != nil {
+		return err
+	}
+
+	diffs, err := Extension(ctx, c, client.ObjectKey{Namespace: flags.namespace, Name: flags.name}, opts)
+	if err != nil {
+		return err
+	}
+
+	return Write(cmd.Root().Writer, diffs)
+}
+
+// newClient returns a client for the dumped resources of the file given by
+// the [flags], or otherwise for the seed cluster of the kubeconfig.
+func (f *flags) newClient() (client.Client, error) {
+	scheme, err := newScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	if f.file != "" {
+		data, err := os.ReadFile(f.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read resources: %w", err)
+		}
+
+		return NewFileClient(scheme, data)
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", f.kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load seed cluster config: %w", err)
+	}
+
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create seed cluster client: %w", err)
+	}
+
+	return c, nil
+}
+
+// newScheme returns a scheme with the resources, which are read for comparing
+// the resources of an Extension.
+func newScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		extensionsv1alpha1.AddToScheme,
+		resourcesv1alpha1.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, fmt.Errorf("failed to create scheme: %w", err)
+		}
+	}
+
+	return scheme, nil
+}
+
+// NewFileClient returns a client for the resources of the given YAML or JSON
+// documents, e.g. as dumped by "kubectl get -o yaml". Lists are expanded to
+// their items.
+func NewFileClient(scheme *runtime.Scheme, data []byte) (client.Client, error) {
+	var objs []client.Object
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		u := &unstructured.Unstructured{}
+		if err := decoder.Decode(&u.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fmt.Errorf("failed to decode resources: %w", err)
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+
+		items := []*unstructured.Unstructured{u}
+		if u.IsList() {
+			list, err := u.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode list: %w", err)
+			}
+
+			items = items[:0]
+			for i := range list.Items {
+				items = append(items, &list.Items[i])
+			}
+		}
+
+		for _, item := range items {
+			obj, err := toTyped(scheme, item)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, obj)
+		}
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(), nil
+}
+
+// toTyped converts the given unstructured object to its type of the given
+// scheme.
+func toTyped(scheme *runtime.Scheme, u *unstructured.Unstructured) (client.Object, error) {
+	gvk := u.GroupVersionKind()
+	obj, err := scheme.New(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s %s: %w", gvk.Kind, u.GetName(), err)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return nil, fmt.Errorf("failed to decode %s %s: %w", gvk.Kind, u.GetName(), err)
+	}
+
+	clientObj, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T of %s %s", obj, gvk.Kind, u.GetName())
+	}
+
+	return clientObj, nil
+}
@@ -0,0 +1,330 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diff provides the CLI command for comparing the resources, which
+// the extension renders for a shoot, with the resources of its deployed
+// ManagedResource.
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/extensions"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/cmd/extension-traefik/internal/render"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+// Change is the kind of change of an object of the ManagedResource.
+type Change string
+
+const (
+	// ChangeAdded is the [Change] of an object, which is only rendered.
+	ChangeAdded Change = "added"
+	// ChangeRemoved is the [Change] of an object, which is only deployed.
+	ChangeRemoved Change = "removed"
+	// ChangeModified is the [Change] of an object, whose rendered fields
+	// differ from the deployed ones.
+	ChangeModified Change = "modified"
+)
+
+// FieldDiff is a field of an object, whose rendered value differs from the
+// deployed one. The values are formatted as JSON and empty, if the field is
+// missing.
+type FieldDiff struct {
+	// Path is the path of the field, e.g. "spec.replicas".
+	Path string
+	// Live is the deployed value of the field.
+	Live string
+	// Desired is the rendered value of the field.
+	Desired string
+}
+
+// ObjectDiff is an object of the ManagedResource, whose rendered version
+// differs from the deployed one.
+type ObjectDiff struct {
+	// Key is the key of the object in the ManagedResource secret.
+	Key string
+	// Object identifies the object by its API version, kind and name.
+	Object string
+	// Change is the kind of change of the object.
+	Change Change
+	// Fields are the changed fields of a modified object.
+	Fields []FieldDiff
+}
+
+// Options are the options for rendering the resources of an extension.
+type Options struct {
+	// OperatorConfig is the optional operator configuration.
+	OperatorConfig *config.TraefikOperatorConfiguration
+	// ImageVector is the image vector of the extension.
+	ImageVector imagevector.ImageVector
+	// Decoder decodes the provider config of the extension.
+	Decoder runtime.Decoder
+}
+
+// Extension returns the differences between the resources of the shoot
+// ManagedResource of the extension with the given key and the resources,
+// which are rendered for the extension with the given options.
+//
+// The deployed credentials of the BasicAuth dashboard are rendered again, so
+// that they are not reported as changed.
+func Extension(ctx context.Context, c client.Client, key client.ObjectKey, opts Options) ([]ObjectDiff, error) {
+	ex := &extensionsv1alpha1.Extension{}
+	if err := c.Get(ctx, key, ex); err != nil {
+		return nil, fmt.Errorf("failed to get extension: %w", err)
+	}
+
+	cluster, err := extensions.GetCluster(ctx, c, ex.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	var providerConfig *config.TraefikConfig
+	if ex.Spec.ProviderConfig != nil && len(ex.Spec.ProviderConfig.Raw) > 0 {
+		providerConfig = &config.TraefikConfig{}
+		if err := runtime.DecodeInto(opts.Decoder, ex.Spec.ProviderConfig.Raw, providerConfig); err != nil {
+			return nil, fmt.Errorf("failed to decode provider config: %w", err)
+		}
+	}
+
+	live, err := traefik.NewDeployer(c, logr.Discard(), traefik.Config{}, nil).DeployedResources(ctx, ex.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := render.Resources(render.Input{
+		OperatorConfig: opts.OperatorConfig,
+		ProviderConfig: providerConfig,
+		Shoot:          cluster.Shoot,
+		Namespace:      ex.Namespace,
+		ImageVector:    opts.ImageVector,
+		DashboardUsers: dashboardUsers(live),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return Objects(live, out.ShootResources)
+}
+
+// dashboardUsers returns the htpasswd entry of the deployed BasicAuth
+// dashboard, or an empty string, if the dashboard is not deployed.
+func dashboardUsers(live map[string][]byte) string {
+	secret := &corev1.Secret{}
+	if err := json.Unmarshal(live["dashboard-auth-secret.yaml"], secret); err != nil {
+		return ""
+	}
+
+	return string(secret.Data["users"])
+}
+
+// Objects returns the differences between the given deployed and rendered
+// resources of a ManagedResource, which are keyed by their name in the
+// ManagedResource secret. The objects are compared semantically, i.e. the
+// formatting and the order of the fields of the objects are not compared.
+func Objects(live, desired map[string][]byte) ([]ObjectDiff, error) {
+	var diffs []ObjectDiff
+	for _, key := range sortedKeys(live, desired) {
+		liveObj, err := decode(live, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode deployed %s: %w", key, err)
+		}
+		desiredObj, err := decode(desired, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode rendered %s: %w", key, err)
+		}
+
+		switch {
+		case liveObj == nil:
+			diffs = append(diffs, ObjectDiff{Key: key, Object: objectName(desiredObj), Change: ChangeAdded})
+		case desiredObj == nil:
+			diffs = append(diffs, ObjectDiff{Key: key, Object: objectName(liveObj), Change: ChangeRemoved})
+		default:
+			fields := compare("", liveObj, desiredObj, nil)
+			if len(fields) > 0 {
+				diffs = append(diffs, ObjectDiff{Key: key, Object: objectName(desiredObj), Change: ChangeModified, Fields: fields})
+			}
+		}
+	}
+
+	return diffs, nil
+}
+
+// sortedKeys returns the sorted keys, which are contained in any of the given
+// maps.
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := slices.AppendSeq(slices.Collect(maps.Keys(a)), maps.Keys(b))
+	slices.Sort(keys)
+
+	return slices.Compact(keys)
+}
+
+// decode decodes the object with the given key of the given resources. It
+// returns nil, if the resources do not contain the key.
+func decode(resources map[string][]byte, key string) (map[string]any, error) {
+	data, ok := resources[key]
+	if !ok {
+		return nil, nil
+	}
+
+	obj := map[string]any{}
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// objectName returns the API version, kind, namespace and name of the given
+// object, e.g. "apps/v1 Deployment kube-system/traefik".
+func objectName(obj map[string]any) string {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	metadata, _ := obj["metadata"].(map[string]any)
+	name, _ := metadata["name"].(string)
+	if namespace, _ := metadata["namespace"].(string); namespace != "" {
+		name = namespace + "/" + name
+	}
+
+	return fmt.Sprintf("%s %s %s", apiVersion, kind, name)
+}
+
+// compare appends the fields, which differ between the given deployed and
+// rendered values, to the given field diffs. Maps are compared by their keys
+// and lists by their indices.
+func compare(path string, live, desired any, diffs []FieldDiff) []FieldDiff {
+	if reflect.DeepEqual(live, desired) {
+		return diffs
+	}
+
+	switch live := live.(type) {
+	case map[string]any:
+		if desired, ok := desired.(map[string]any); ok {
+			for _, key := range sortedKeys(live, desired) {
+				diffs = compareMember(fieldPath(path, key), live, desired, key, diffs)
+			}
+
+			return diffs
+		}
+	case []any:
+		if desired, ok := desired.([]any); ok {
+			for i := range max(len(live), len(desired)) {
+				itemPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(live):
+					diffs = append(diffs, FieldDiff{Path: itemPath, Desired: format(desired[i])})
+				case i >= len(desired):
+					diffs = append(diffs, FieldDiff{Path: itemPath, Live: format(live[i])})
+				default:
+					diffs = compare(itemPath, live[i], desired[i], diffs)
+				}
+			}
+
+			return diffs
+		}
+	}
+
+	return append(diffs, FieldDiff{Path: path, Live: format(live), Desired: format(desired)})
+}
+
+// compareMember compares the member with the given key of the given deployed
+// and rendered maps.
+func compareMember(path string, live, desired map[string]any, key string, diffs []FieldDiff) []FieldDiff {
+	liveValue, inLive := live[key]
+	desiredValue, inDesired := desired[key]
+
+	switch {
+	case !inLive:
+		return append(diffs, FieldDiff{Path: path, Desired: format(desiredValue)})
+	case !inDesired:
+		return append(diffs, FieldDiff{Path: path, Live: format(liveValue)})
+	default:
+		return compare(path, liveValue, desiredValue, diffs)
+	}
+}
+
+// identifier matches the keys of maps, which can be appended to a field path
+// with a dot.
+var identifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// fieldPath returns the path of the member with the given key of the map at
+// the given path.
+func fieldPath(path, key string) string {
+	if !identifier.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// format formats the given value as JSON.
+func format(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(data)
+}
+
+// Write writes the given differences to the given writer. Every object is
+// printed with a "+" for added, a "-" for removed and a "~" for modified
+// objects, followed by its changed fields.
+func Write(w io.Writer, diffs []ObjectDiff) error {
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintln(w, "No differences")
+
+		return err
+	}
+
+	var b strings.Builder
+	for _, diff := range diffs {
+		switch diff.Change {
+		case ChangeAdded:
+			fmt.Fprintf(&b, "+ %s: %s\n", diff.Key, diff.Object)
+		case ChangeRemoved:
+			fmt.Fprintf(&b, "- %s: %s\n", diff.Key, diff.Object)
+		case ChangeModified:
+			fmt.Fprintf(&b, "~ %s: %s\n", diff.Key, diff.Object)
+		}
+
+		for _, field := range diff.Fields {
+			fmt.Fprintf(&b, "    %s: %s -> %s\n", field.Path, formatValue(field.Live), formatValue(field.Desired))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// formatValue returns the given formatted value, or "<none>" for a missing
+// field.
+func formatValue(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+
+	return value
+}
@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/cmd/extension-traefik/internal/render"
+	extenimagev "github.com/gardener/gardener-extension-shoot-traefik/imagevector"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+const namespace = "shoot--foo--bar"
+
+func TestObjects(t *testing.T) {
+	live := map[string][]byte{
+		"deployment.yaml": []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"traefik","namespace":"kube-system","labels":{"app.kubernetes.io/name":"traefik"}},"spec":{"replicas":2,"args":["--a","--b"]}}`),
+		"service.yaml":    []byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"traefik","namespace":"kube-system"}}`),
+		"old.yaml":        []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"old","namespace":"kube-system"}}`),
+	}
+	desired := map[string][]byte{
+		"deployment.yaml": []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: traefik\n  namespace: kube-system\n  labels:\n    app.kubernetes.io/name: ingress\nspec:\n  replicas: 3\n  args: [--a]\n  paused: false\n"),
+		"service.yaml":    []byte(`{"kind":"Service","apiVersion":"v1","metadata":{"namespace":"kube-system","name":"traefik"}}`),
+		"class.yaml":      []byte(`{"apiVersion":"networking.k8s.io/v1","kind":"IngressClass","metadata":{"name":"traefik"}}`),
+	}
+
+	diffs, err := Objects(live, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []ObjectDiff{
+		{Key: "class.yaml", Object: "networking.k8s.io/v1 IngressClass traefik", Change: ChangeAdded},
+		{
+			Key:    "deployment.yaml",
+			Object: "apps/v1 Deployment kube-system/traefik",
+			Change: ChangeModified,
+			Fields: []FieldDiff{
+				{Path: `metadata.labels["app.kubernetes.io/name"]`, Live: `"traefik"`, Desired: `"ingress"`},
+				{Path: "spec.args[1]", Live: `"--b"`},
+				{Path: "spec.paused", Desired: "false"},
+				{Path: "spec.replicas", Live: "2", Desired: "3"},
+			},
+		},
+		{Key: "old.yaml", Object: "v1 ConfigMap kube-system/old", Change: ChangeRemoved},
+	}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Fatalf("expected diffs %+v, got: %+v", expected, diffs)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, diffs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range []string{
+		"+ class.yaml: networking.k8s.io/v1 IngressClass traefik\n",
+		"~ deployment.yaml: apps/v1 Deployment kube-system/traefik\n",
+		"    spec.args[1]: \"--b\" -> <none>\n",
+		"    spec.replicas: 2 -> 3\n",
+		"- old.yaml: v1 ConfigMap kube-system/old\n",
+	} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("expected output to contain %q, got:\n%s", line, buf.String())
+		}
+	}
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme, err := newScheme()
+	if err != nil {
+		t.Fatalf("failed to create scheme: %v", err)
+	}
+
+	return scheme
+}
+
+// newClient returns a fake client with the Cluster and the Extension of a shoot
+// with the given provider config.
+func newClient(t *testing.T, providerConfig string) client.Client {
+	t.Helper()
+
+	shoot := &gardencorev1beta1.Shoot{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gardencorev1beta1.SchemeGroupVersion.String(), Kind: "Shoot"},
+		ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "garden-foo"},
+		Spec: gardencorev1beta1.ShootSpec{
+			Provider: gardencorev1beta1.Provider{
+				Workers: []gardencorev1beta1.Worker{{Name: "a", Zones: []string{"z1"}}},
+			},
+		},
+	}
+	shootData, err := json.Marshal(shoot)
+	if err != nil {
+		t.Fatalf("failed to encode shoot: %v", err)
+	}
+
+	cluster := &extensionsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		Spec:       extensionsv1alpha1.ClusterSpec{Shoot: runtime.RawExtension{Raw: shootData}},
+	}
+	ex := &extensionsv1alpha1.Extension{
+		ObjectMeta: metav1.ObjectMeta{Name: "shoot-traefik", Namespace: namespace},
+		Spec: extensionsv1alpha1.ExtensionSpec{
+			DefaultSpec: extensionsv1alpha1.DefaultSpec{
+				Type:           "shoot-traefik",
+				ProviderConfig: &runtime.RawExtension{Raw: []byte(providerConfig)},
+			},
+		},
+	}
+
+	return fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(cluster, ex).Build()
+}
+
+// deploy deploys the ManagedResource for the given provider config spec like
+// the actuator.
+func deploy(t *testing.T, c client.Client, spec *config.TraefikConfigSpec) {
+	t.Helper()
+
+	cfg, err := traefik.NewConfig(nil, spec)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	cfg.Zones = []string{"z1"}
+
+	if err := traefik.NewDeployer(c, logr.Discard(), cfg, extenimagev.ImageVector()).Deploy(context.Background(), namespace); err != nil {
+		t.Fatalf("failed to deploy: %v", err)
+	}
+}
+
+func TestExtension(t *testing.T) {
+	opts := Options{ImageVector: extenimagev.ImageVector(), Decoder: render.NewDecoder()}
+	key := client.ObjectKey{Namespace: namespace, Name: "shoot-traefik"}
+	providerConfig := `{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"replicas":3}}`
+
+	t.Run("unchanged", func(t *testing.T) {
+		c := newClient(t, providerConfig)
+		deploy(t, c, &config.TraefikConfigSpec{Replicas: 3})
+
+		diffs, err := Extension(context.Background(), c, key, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(diffs) != 0 {
+			t.Errorf("expected no diffs, got: %+v", diffs)
+		}
+	})
+
+	t.Run("changed replicas", func(t *testing.T) {
+		c := newClient(t, providerConfig)
+		deploy(t, c, &config.TraefikConfigSpec{Replicas: 2})
+
+		diffs, err := Extension(context.Background(), c, key, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []ObjectDiff{{
+			Key:    "deployment.yaml",
+			Object: "apps/v1 Deployment kube-system/traefik",
+			Change: ChangeModified,
+			Fields: []FieldDiff{{Path: "spec.replicas", Live: "2", Desired: "3"}},
+		}}
+		if !reflect.DeepEqual(diffs, expected) {
+			t.Errorf("expected diffs %+v, got: %+v", expected, diffs)
+		}
+	})
+
+	t.Run("not deployed", func(t *testing.T) {
+		c := newClient(t, providerConfig)
+
+		diffs, err := Extension(context.Background(), c, key, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(diffs) == 0 {
+			t.Fatal("expected diffs")
+		}
+		for _, diff := range diffs {
+			if diff.Change != ChangeAdded {
+				t.Errorf("expected %s to be added, got: %s", diff.Key, diff.Change)
+			}
+		}
+	})
+}
+
+func TestNewFileClient(t *testing.T) {
+	// The deployment.yaml of the secret is
+	// {"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"traefik","namespace":"kube-system"},"spec":{"replicas":1}}
+	data := `apiVersion: v1
+kind: List
+items:
+- apiVersion: extensions.gardener.cloud/v1alpha1
+  kind: Cluster
+  metadata:
+    name: shoot--foo--bar
+    resourceVersion: "42"
+  spec:
+    cloudProfile: {}
+    seed: {}
+    shoot:
+      apiVersion: core.gardener.cloud/v1beta1
+      kind: Shoot
+      metadata:
+        name: bar
+        namespace: garden-foo
+- apiVersion: extensions.gardener.cloud/v1alpha1
+  kind: Extension
+  metadata:
+    name: shoot-traefik
+    namespace: shoot--foo--bar
+  spec:
+    type: shoot-traefik
+---
+apiVersion: resources.gardener.cloud/v1alpha1
+kind: ManagedResource
+metadata:
+  name: extension-traefik
+  namespace: shoot--foo--bar
+spec:
+  secretRefs:
+  - name: managedresource-extension-traefik-abc
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: managedresource-extension-traefik-abc
+  namespace: shoot--foo--bar
+data:
+  deployment.yaml: eyJhcGlWZXJzaW9uIjoiYXBwcy92MSIsImtpbmQiOiJEZXBsb3ltZW50IiwibWV0YWRhdGEiOnsibmFtZSI6InRyYWVmaWsiLCJuYW1lc3BhY2UiOiJrdWJlLXN5c3RlbSJ9LCJzcGVjIjp7InJlcGxpY2FzIjoxfX0=
+`
+
+	c, err := NewFileClient(testScheme(t), []byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts := Options{ImageVector: extenimagev.ImageVector(), Decoder: render.NewDecoder()}
+	diffs, err := Extension(context.Background(), c, client.ObjectKey{Namespace: namespace, Name: "shoot-traefik"}, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var deployment *ObjectDiff
+	for i := range diffs {
+		if diffs[i].Key == "deployment.yaml" {
+			deployment = &diffs[i]
+		}
+	}
+	if deployment == nil || deployment.Change != ChangeModified {
+		t.Fatalf("expected a modified deployment, got: %+v", diffs)
+	}
+	if !slices.Contains(deployment.Fields, FieldDiff{Path: "spec.replicas", Live: "1", Desired: "2"}) {
+		t.Errorf("expected replicas to change from 1 to 2, got: %+v", deployment.Fields)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
 	glogger "github.com/gardener/gardener/pkg/logger"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
 	"github.com/urfave/cli/v3"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -24,6 +25,7 @@ import (
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
 	"k8s.io/component-base/featuregate"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -33,6 +35,9 @@ import (
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/controller"
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/heartbeat"
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/mgr"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/operatorconfig"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/version"
 )
 
 // flags stores the manager flags as provided from the command-line
@@ -48,12 +53,17 @@ type flags struct {
 	ignoreOperationAnnotation bool
 	maxConcurrentReconciles   int
 	kubeconfig                string
+	gardenKubeconfig          string
 	zapLogLevel               string
 	zapLogFormat              string
 	resyncInterval            time.Duration
+	rolloutTimeout            time.Duration
+	nginxCheckInterval        time.Duration
 	pprofBindAddr             string
 	clientConnQPS             float32
 	clientConnBurst           int32
+	traefikSupportedVersions  []string
+	configFile                string
 
 	// The following flags are meant to be specified by the Helm chart,
 	// which gardenlet will invoke during deployment. The value of each flag
@@ -91,27 +101,41 @@ func (f *flags) getManager(ctx context.Context) (ctrl.Manager, error) {
 		}),
 	}
 
-	m, err := mgr.New(opts...)
+	return mgr.New(opts...)
+}
 
-	if err != nil {
-		return nil, err
+// setupHeartbeat registers the heartbeat controller with the given
+// [ctrl.Manager]. The heartbeat lease is annotated with the version of the
+// extension, the default Traefik version of the given image vector and the
+// state of the managed shoots.
+func (f *flags) setupHeartbeat(ctx context.Context, m ctrl.Manager, imageVector imagevector.ImageVector) error {
+	traefikVersion := "unknown"
+	if img, err := traefik.FindImage(imageVector, ""); err == nil && img.Version != nil {
+		traefikVersion = *img.Version
 	}
 
 	hb, err := heartbeat.New(
 		heartbeat.WithExtensionName(f.extensionName),
 		heartbeat.WithLeaseNamespace(f.heartbeatNamespace),
 		heartbeat.WithRenewInterval(f.heartbeatRenewInterval),
+		heartbeat.WithExtensionType(actuator.ExtensionType),
+		heartbeat.WithInfo(func() heartbeat.Info {
+			return heartbeat.Info{
+				Version:        version.Version,
+				TraefikVersion: traefikVersion,
+			}
+		}),
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to create heartbeat controller: %w", err)
+		return fmt.Errorf("failed to create heartbeat controller: %w", err)
 	}
 
 	if err := hb.SetupWithManager(ctx, m); err != nil {
-		return nil, fmt.Errorf("failed to setup heartbeat controller: %w", err)
+		return fmt.Errorf("failed to setup heartbeat controller: %w", err)
 	}
 
-	return m, nil
+	return nil
 }
 
 // flagsKey is the key used to store the parsed command-line flags in a
@@ -224,6 +248,12 @@ func New() *cli.Command {
 					return os.Setenv(clientcmd.RecommendedConfigPathEnvVar, val)
 				},
 			},
+			&cli.StringFlag{
+				Name:        "garden-kubeconfig",
+				Usage:       "path to a kubeconfig for the garden cluster, which is injected by gardenlet",
+				Sources:     cli.EnvVars("GARDEN_KUBECONFIG"),
+				Destination: &flags.gardenKubeconfig,
+			},
 			&cli.StringFlag{
 				Name:  "log-level",
 				Usage: "Zap Level to configure the verbosity of logging",
@@ -257,6 +287,20 @@ func New() *cli.Command {
 				Sources:     cli.EnvVars("RESYNC_INTERVAL"),
 				Destination: &flags.resyncInterval,
 			},
+			&cli.DurationFlag{
+				Name:        "rollout-timeout",
+				Usage:       "progress deadline of the traefik deployment in a shoot cluster, after which a rollout fails, 0 disables the verification of rollouts",
+				Value:       traefik.DefaultRolloutTimeout,
+				Sources:     cli.EnvVars("ROLLOUT_TIMEOUT"),
+				Destination: &flags.rolloutTimeout,
+			},
+			&cli.DurationFlag{
+				Name:        "nginx-compatibility-check-interval",
+				Usage:       "min interval between the checks of the nginx annotations in a shoot cluster using the KubernetesIngressNGINX provider",
+				Value:       actuator.DefaultNginxCompatibilityCheckInterval,
+				Sources:     cli.EnvVars("NGINX_COMPATIBILITY_CHECK_INTERVAL"),
+				Destination: &flags.nginxCheckInterval,
+			},
 			&cli.Float32Flag{
 				Name:        "client-conn-qps",
 				Usage:       "allowed client queries per second for the connection",
@@ -271,6 +315,18 @@ func New() *cli.Command {
 				Sources:     cli.EnvVars("CLIENT_CONNECTION_BURST"),
 				Destination: &flags.clientConnBurst,
 			},
+			&cli.StringFlag{
+				Name:        "config",
+				Usage:       "path to the operator configuration file, which is reloaded on changes",
+				Sources:     cli.EnvVars("CONFIG_FILE"),
+				Destination: &flags.configFile,
+			},
+			&cli.StringSliceFlag{
+				Name:        "traefik-supported-version",
+				Usage:       "traefik version offered to shoot owners, defaults to all versions of the image vector",
+				Sources:     cli.EnvVars("TRAEFIK_SUPPORTED_VERSIONS"),
+				Destination: &flags.traefikSupportedVersions,
+			},
 			// The following flags are meant to be specified by the
 			// Helm chart, which is rendered and deployed by the
 			// gardenlet.
@@ -324,13 +380,56 @@ func runManager(ctx context.Context, cmd *cli.Command) error {
 	logger.Info("creating actuators")
 	decoder := serializer.NewCodecFactory(m.GetScheme(), serializer.EnableStrict).UniversalDecoder()
 	imageVector := extenimagev.ImageVector()
-	act, err := actuator.New(
-		m.GetClient(),
-		imageVector,
+	if len(flags.traefikSupportedVersions) > 0 {
+		imageVector, err = traefik.FilterVersions(imageVector, flags.traefikSupportedVersions)
+		if err != nil {
+			return fmt.Errorf("invalid supported traefik versions: %w", err)
+		}
+	}
+	logger.Info("configured supported traefik versions", "versions", traefik.SupportedVersions(imageVector))
+
+	if err := flags.setupHeartbeat(ctx, m, imageVector); err != nil {
+		return err
+	}
+
+	actuatorOpts := []actuator.Option{
 		actuator.WithDecoder(decoder),
 		actuator.WithGardenerVersion(flags.gardenerVersion),
 		actuator.WithGardenletFeatures(flags.gardenletFeatureGates),
-	)
+		actuator.WithRolloutTimeout(flags.rolloutTimeout),
+		actuator.WithNginxCompatibilityCheckInterval(flags.nginxCheckInterval),
+		actuator.WithEventRecorder(m.GetEventRecorder(flags.extensionName)),
+	}
+
+	if flags.configFile != "" {
+		logger.Info("loading operator configuration", "path", flags.configFile)
+		operatorConfig, err := operatorconfig.Load(flags.configFile, decoder)
+		if err != nil {
+			return err
+		}
+
+		store := operatorconfig.NewStore(operatorConfig)
+		if err := m.Add(operatorconfig.NewWatcher(flags.configFile, decoder, store, ctrllog.Log)); err != nil {
+			return fmt.Errorf("failed to setup operator configuration watcher: %w", err)
+		}
+		actuatorOpts = append(actuatorOpts, actuator.WithOperatorConfig(store))
+	}
+
+	if flags.gardenKubeconfig != "" {
+		logger.Info("creating garden client", "kubeconfig", flags.gardenKubeconfig)
+		gardenConfig, err := clientcmd.BuildConfigFromFlags("", flags.gardenKubeconfig)
+		if err != nil {
+			return fmt.Errorf("failed to load garden kubeconfig: %w", err)
+		}
+
+		gardenClient, err := client.New(gardenConfig, client.Options{Scheme: m.GetScheme()})
+		if err != nil {
+			return fmt.Errorf("failed to create garden client: %w", err)
+		}
+		actuatorOpts = append(actuatorOpts, actuator.WithGardenClient(gardenClient))
+	}
+
+	act, err := actuator.New(m.GetClient(), imageVector, actuatorOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create actuator: %w", err)
 	}
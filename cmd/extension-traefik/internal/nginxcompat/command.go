@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nginxcompat provides the CLI command for checking the support of the
+// nginx annotations of the Ingress resources in a shoot cluster by Traefik.
+package nginxcompat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+const (
+	// outputText is the output format of a table.
+	outputText = "text"
+	// outputYAML is the output format of the report as YAML, like in the
+	// ConfigMap published by the extension.
+	outputYAML = "yaml"
+)
+
+// flags stores the nginx-compatibility flags as provided from the
+// command-line
+type flags struct {
+	kubeconfig string
+	namespaces []string
+	output     string
+}
+
+// New creates a new [cli.Command] for checking the support of the nginx
+// annotations in a shoot cluster by Traefik.
+func New() *cli.Command {
+	flags := flags{}
+
+	cmd := &cli.Command{
+		Name:  "nginx-compatibility",
+		Usage: "check which nginx annotations of the Ingress resources in a shoot cluster are supported by Traefik",
+		Description: "Lists the Ingress resources of the nginx ingress class in the shoot cluster and classifies " +
+			"their nginx.ingress.kubernetes.io/* annotations as supported, partially supported or unsupported " +
+			"by the KubernetesIngressNGINX provider of Traefik. Only the partially supported and unsupported " +
+			"annotations are listed.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "kubeconfig",
+				Usage:       "path to a kubeconfig for the shoot cluster",
+				Sources:     cli.EnvVars(clientcmd.RecommendedConfigPathEnvVar),
+				Destination: &flags.kubeconfig,
+			},
+			&cli.StringSliceFlag{
+				Name:        "namespace",
+				Usage:       "namespace of the Ingress resources, defaults to all namespaces",
+				Destination: &flags.namespaces,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "output format, one of text or yaml",
+				Value:       outputText,
+				Destination: &flags.output,
+				Validator: func(output string) error {
+					if output != outputText && output != outputYAML {
+						return fmt.Errorf("invalid output format %q", output)
+					}
+
+					return nil
+				},
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runCheck(ctx, cmd, &flags)
+		},
+	}
+
+	return cmd
+}
+
+// runCheck checks the nginx annotations in the shoot cluster given by the
+// [flags] and writes the report to the writer of the command.
+func runCheck(ctx context.Context, cmd *cli.Command, flags *flags) error {
+	config, err := clientcmd.BuildConfigFromFlags("", flags.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load shoot cluster config: %w", err)
+	}
+
+	c, err := client.New(config, client.Options{Scheme: clientgoscheme.Scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create shoot cluster client: %w", err)
+	}
+
+	report, err := traefik.AnalyzeNginxCompatibility(ctx, c, traefik.Config{Namespaces: flags.namespaces})
+	if err != nil {
+		return err
+	}
+
+	return Write(cmd.Root().Writer, report, flags.output)
+}
+
+// Write writes the given report to the given writer in the given output
+// format, which is either "text" for a table or "yaml".
+func Write(w io.Writer, report *traefik.NginxCompatibilityReport, output string) error {
+	if output == outputYAML {
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		_, err = w.Write(data)
+
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Checked %d Ingress resources: %d supported, %d partially supported and %d unsupported nginx annotations\n",
+		report.Ingresses, report.Supported, report.PartiallySupported, report.Unsupported)
+	if len(report.Incompatible) == 0 {
+		return tw.Flush()
+	}
+
+	fmt.Fprintln(tw, "\nINGRESS\tANNOTATION\tSUPPORT\tNOTE")
+	for _, ing := range report.Incompatible {
+		for _, annotation := range ing.Annotations {
+			fmt.Fprintf(tw, "%s/%s\t%s\t%s\t%s\n", ing.Namespace, ing.Name, annotation.Annotation, annotation.Support, annotation.Note)
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nginxcompat
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+func newReport() *traefik.NginxCompatibilityReport {
+	return &traefik.NginxCompatibilityReport{
+		Ingresses:          2,
+		Supported:          3,
+		PartiallySupported: 1,
+		Unsupported:        1,
+		Incompatible: []traefik.IngressCompatibility{{
+			Namespace: "default",
+			Name:      "shop",
+			Annotations: []traefik.AnnotationCompatibility{
+				{Annotation: "nginx.ingress.kubernetes.io/affinity-mode", Support: traefik.AnnotationPartiallySupported, Note: "persistent mode"},
+				{Annotation: "nginx.ingress.kubernetes.io/limit-rps", Support: traefik.AnnotationUnsupported, Note: "use a RateLimit middleware"},
+			},
+		}},
+	}
+}
+
+func TestWrite_Text(t *testing.T) {
+	var out bytes.Buffer
+	if err := Write(&out, newReport(), outputText); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected summary, header and two annotations, got:\n%s", out.String())
+	}
+	if !strings.HasPrefix(lines[0], "Checked 2 Ingress resources: 3 supported, 1 partially supported and 1 unsupported") {
+		t.Errorf("unexpected summary: %s", lines[0])
+	}
+	if fields := strings.Fields(lines[4]); len(fields) < 3 || fields[0] != "default/shop" ||
+		fields[1] != "nginx.ingress.kubernetes.io/limit-rps" || fields[2] != string(traefik.AnnotationUnsupported) {
+		t.Errorf("unexpected annotation line: %s", lines[4])
+	}
+}
+
+func TestWrite_TextCompatible(t *testing.T) {
+	var out bytes.Buffer
+	if err := Write(&out, &traefik.NginxCompatibilityReport{Ingresses: 1, Supported: 2}, outputText); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(out.String(), "ANNOTATION") {
+		t.Errorf("expected no table without incompatible annotations, got:\n%s", out.String())
+	}
+}
+
+func TestWrite_YAML(t *testing.T) {
+	var out bytes.Buffer
+	report := newReport()
+	if err := Write(&out, report, outputYAML); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded := &traefik.NginxCompatibilityReport{}
+	if err := yaml.Unmarshal(out.Bytes(), decoded); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, report) {
+		t.Errorf("expected report %+v, got: %+v", report, decoded)
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	extenimagev "github.com/gardener/gardener-extension-shoot-traefik/imagevector"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	configinstall "github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config/install"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/operatorconfig"
+)
+
+// flags stores the render flags as provided from the command-line
+type flags struct {
+	providerConfigFile string
+	shootFile          string
+	configFile         string
+	namespace          string
+	ingressAddresses   []string
+	dnsProviderType    string
+}
+
+// New creates a new [cli.Command] for rendering the resources of the
+// extension for a shoot.
+func New() *cli.Command {
+	flags := flags{}
+
+	cmd := &cli.Command{
+		Name:  "render",
+		Usage: "render the resources deployed for a shoot without access to any cluster",
+		Description: "Renders the resources of the shoot ManagedResource and the ingress DNS record, " +
+			"which the extension deploys for the given shoot and provider config, as multi-document YAML. " +
+			"As the shoot cluster is not contacted, the backends of the Restricted NetworkPolicy mode " +
+			"are not discovered and the credentials of the BasicAuth dashboard are a placeholder.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "provider-config",
+				Usage:       "path to the TraefikConfig of the shoot, defaults to no provider config",
+				Destination: &flags.providerConfigFile,
+			},
+			&cli.StringFlag{
+				Name:        "shoot",
+				Usage:       "path to the Shoot or Cluster resource of the shoot",
+				Required:    true,
+				Destination: &flags.shootFile,
+			},
+			&cli.StringFlag{
+				Name:        "config",
+				Usage:       "path to the operator configuration file",
+				Destination: &flags.configFile,
+			},
+			&cli.StringFlag{
+				Name:        "namespace",
+				Usage:       "control plane namespace of the shoot, defaults to the namespace derived from the shoot",
+				Destination: &flags.namespace,
+			},
+			&cli.StringSliceFlag{
+				Name:        "ingress-address",
+				Usage:       "address of traefik, which the ingress DNS record points to",
+				Value:       []string{DefaultIngressAddress},
+				Destination: &flags.ingressAddresses,
+			},
+			&cli.StringFlag{
+				Name:        "dns-provider-type",
+				Usage:       "type of the DNS provider of the ingress DNS record, defaults to the primary DNS provider of the shoot",
+				Destination: &flags.dnsProviderType,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runRender(cmd, &flags)
+		},
+	}
+
+	return cmd
+}
+
+// runRender renders the resources for the shoot given by the [flags] and
+// writes them to the writer of the command.
+func runRender(cmd *cli.Command, flags *flags) error {
+	decoder := NewDecoder()
+
+	shootData, err := os.ReadFile(flags.shootFile)
+	if err != nil {
+		return fmt.Errorf("failed to read shoot: %w", err)
+	}
+	shoot, namespace, err := DecodeShoot(shootData)
+	if err != nil {
+		return err
+	}
+
+	in := Input{
+		Shoot:            shoot,
+		Namespace:        namespace,
+		ImageVector:      extenimagev.ImageVector(),
+		IngressAddresses: flags.ingressAddresses,
+		DNSProviderType:  flags.dnsProviderType,
+	}
+	if flags.namespace != "" {
+		in.Namespace = flags.namespace
+	}
+
+	if flags.providerConfigFile != "" {
+		data, err := os.ReadFile(flags.providerConfigFile)
+		if err != nil {
+			return fmt.Errorf("failed to read provider config: %w", err)
+		}
+
+		in.ProviderConfig = &config.TraefikConfig{}
+		if err := runtime.DecodeInto(decoder, data, in.ProviderConfig); err != nil {
+			return fmt.Errorf("failed to decode provider config: %w", err)
+		}
+	}
+
+	if flags.configFile != "" {
+		in.OperatorConfig, err = operatorconfig.Load(flags.configFile, decoder)
+		if err != nil {
+			return err
+		}
+	}
+
+	out, err := Resources(in)
+	if err != nil {
+		return err
+	}
+
+	return WriteYAML(cmd.Root().Writer, out)
+}
+
+// NewDecoder returns a strict decoder for the configuration API of the
+// extension, which applies the defaults of the API like the extension.
+func NewDecoder() runtime.Decoder {
+	scheme := runtime.NewScheme()
+	configinstall.Install(scheme)
+
+	return serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder()
+}
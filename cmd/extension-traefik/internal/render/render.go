@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package render provides the CLI command for rendering the resources, which
+// the extension deploys for a shoot, without access to any cluster.
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/extensions"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config/validation"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+// ErrInvalidInput is an error, which is returned when the resources cannot be
+// rendered from the given input.
+var ErrInvalidInput = errors.New("invalid render input")
+
+// DefaultIngressAddress is the placeholder address of Traefik, which the
+// rendered ingress DNS record points to, if no address is given. It is taken
+// from the TEST-NET-1 range, which is reserved for documentation.
+const DefaultIngressAddress = "192.0.2.1"
+
+// placeholderDashboardUsers is the htpasswd entry of the BasicAuth dashboard,
+// which is rendered instead of the generated credentials of the shoot.
+var placeholderDashboardUsers = traefik.DashboardUsername + ":<bcrypt hash of the generated password>"
+
+// Input are the inputs for rendering the resources of the extension for a
+// shoot.
+type Input struct {
+	// OperatorConfig is the optional operator configuration.
+	OperatorConfig *config.TraefikOperatorConfiguration
+	// ProviderConfig is the optional provider config of the shoot.
+	ProviderConfig *config.TraefikConfig
+	// Shoot is the shoot, for which the resources are rendered.
+	Shoot *gardencorev1beta1.Shoot
+	// Namespace is the control plane namespace of the shoot.
+	Namespace string
+	// ImageVector is the image vector of the extension.
+	ImageVector imagevector.ImageVector
+	// IngressAddresses are the addresses, which the ingress DNS record
+	// points to. Defaults to [DefaultIngressAddress].
+	IngressAddresses []string
+	// DNSProviderType is the type of the DNS provider of the ingress DNS
+	// record. Defaults to the type of the primary DNS provider of the shoot.
+	DNSProviderType string
+	// DashboardUsers is the htpasswd entry of the BasicAuth dashboard.
+	// Defaults to a placeholder, because the credentials are generated by
+	// the extension.
+	DashboardUsers string
+}
+
+// Output are the rendered resources of the extension for a shoot.
+type Output struct {
+	// ShootResources are the resources of the shoot ManagedResource keyed by
+	// their name in the ManagedResource secret.
+	ShootResources map[string][]byte
+	// SeedResources are the resources of the seed ManagedResource of the
+	// ingress DNS record keyed by their name in the ManagedResource secret.
+	// They are nil, if no ingress DNS record is deployed for the shoot.
+	SeedResources map[string][]byte
+}
+
+// Resources renders the resources, which the extension deploys for the shoot
+// of the given [Input]. The provider config is defaulted and validated like
+// by the extension.
+//
+// As the shoot cluster is not contacted, the backends of Traefik in the
+// Restricted NetworkPolicy mode are not discovered and a dynamic config
+// ConfigMap is not validated.
+func Resources(in Input) (*Output, error) {
+	if in.Shoot == nil {
+		return nil, fmt.Errorf("%w: missing shoot", ErrInvalidInput)
+	}
+	if in.Namespace == "" {
+		return nil, fmt.Errorf("%w: missing control plane namespace", ErrInvalidInput)
+	}
+
+	var spec *config.TraefikConfigSpec
+	if in.ProviderConfig != nil {
+		if err := validation.ValidateTraefikConfig(in.ProviderConfig).ToAggregate(); err != nil {
+			return nil, fmt.Errorf("%w: invalid provider config: %w", ErrInvalidInput, err)
+		}
+		spec = &in.ProviderConfig.Spec
+	}
+
+	traefikConfig, err := traefik.NewConfig(in.OperatorConfig, spec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := traefik.FindImage(in.ImageVector, traefikConfig.Version); err != nil {
+		return nil, err
+	}
+	traefikConfig.ApplyShoot(in.Shoot)
+	if traefikConfig.DashboardCredentialsRequired() {
+		traefikConfig.SecureDashboard.Users = in.DashboardUsers
+		if traefikConfig.SecureDashboard.Users == "" {
+			traefikConfig.SecureDashboard.Users = placeholderDashboardUsers
+		}
+	}
+
+	deployer := traefik.NewDeployer(nil, logr.Discard(), traefikConfig, in.ImageVector)
+	shootResources, err := deployer.Resources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render traefik resources: %w", err)
+	}
+	out := &Output{ShootResources: shootResources}
+
+	if !traefikConfig.DNSRecordEnabled(in.Shoot) {
+		return out, nil
+	}
+
+	addresses := in.IngressAddresses
+	if len(addresses) == 0 {
+		addresses = []string{DefaultIngressAddress}
+	}
+	providerType := in.DNSProviderType
+	if providerType == "" {
+		providerType = primaryDNSProviderType(in.Shoot)
+	}
+	// The ingress DNS record uses the credentials of the external DNS record
+	// of the shoot, which gardenlet creates with this secret.
+	secretRef := corev1.SecretReference{
+		Name:      fmt.Sprintf("dnsrecord-%s-external", in.Shoot.Name),
+		Namespace: in.Namespace,
+	}
+	dnsName := fmt.Sprintf("*.%s.%s", gardenerutils.IngressPrefix, traefik.ShootDomain(in.Shoot))
+
+	out.SeedResources, err = deployer.DNSRecordResources(in.Namespace, addresses, dnsName, providerType, secretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render ingress DNS record: %w", err)
+	}
+
+	return out, nil
+}
+
+// WriteYAML writes the resources of the given [Output] as multi-document YAML
+// to the given writer. Every document is preceded by a comment with the name
+// of its ManagedResource and its key in the ManagedResource secret.
+func WriteYAML(w io.Writer, out *Output) error {
+	if err := writeResources(w, traefik.ManagedResourceName, out.ShootResources); err != nil {
+		return err
+	}
+
+	return writeResources(w, traefik.SeedManagedResourceName, out.SeedResources)
+}
+
+// writeResources writes the given resources of the ManagedResource with the
+// given name as YAML documents sorted by their key.
+func writeResources(w io.Writer, managedResourceName string, resources map[string][]byte) error {
+	for _, key := range slices.Sorted(maps.Keys(resources)) {
+		data, err := yaml.JSONToYAML(resources[key])
+		if err != nil {
+			return fmt.Errorf("failed to convert %s to YAML: %w", key, err)
+		}
+
+		if _, err := fmt.Fprintf(w, "---\n# Source: %s/%s\n%s", managedResourceName, key, data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// DecodeShoot decodes the shoot from the given YAML, which contains either a
+// Shoot or a Cluster resource, and returns it together with its control plane
+// namespace.
+func DecodeShoot(data []byte) (*gardencorev1beta1.Shoot, string, error) {
+	scheme := runtime.NewScheme()
+	if err := gardencorev1beta1.AddToScheme(scheme); err != nil {
+		return nil, "", err
+	}
+	if err := extensionsv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, "", err
+	}
+
+	obj, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode shoot: %w", err)
+	}
+
+	switch obj := obj.(type) {
+	case *gardencorev1beta1.Shoot:
+		projectName := strings.TrimPrefix(obj.Namespace, "garden-")
+
+		return obj, gardenerutils.ComputeTechnicalID(projectName, obj), nil
+	case *extensionsv1alpha1.Cluster:
+		shoot, err := extensions.ShootFromCluster(obj)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to decode shoot of cluster: %w", err)
+		}
+		if shoot == nil {
+			return nil, "", fmt.Errorf("%w: cluster %s has no shoot", ErrInvalidInput, obj.Name)
+		}
+
+		return shoot, obj.Name, nil
+	default:
+		return nil, "", fmt.Errorf("%w: expected a Shoot or a Cluster, got %T", ErrInvalidInput, obj)
+	}
+}
+
+// primaryDNSProviderType returns the type of the primary DNS provider of the
+// shoot, or "unknown", if the shoot does not specify one.
+func primaryDNSProviderType(shoot *gardencorev1beta1.Shoot) string {
+	for _, provider := range shoot.Spec.DNS.Providers {
+		if provider.Primary != nil && *provider.Primary && provider.Type != nil {
+			return *provider.Type
+		}
+	}
+
+	return "unknown"
+}
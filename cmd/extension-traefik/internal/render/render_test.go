@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	extenimagev "github.com/gardener/gardener-extension-shoot-traefik/imagevector"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+func newShoot() *gardencorev1beta1.Shoot {
+	return &gardencorev1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "garden-foo"},
+		Spec: gardencorev1beta1.ShootSpec{
+			DNS: &gardencorev1beta1.DNS{
+				Domain: new("bar.foo.example.com"),
+				Providers: []gardencorev1beta1.DNSProvider{
+					{Type: new("aws-route53"), Primary: new(true)},
+				},
+			},
+			Provider: gardencorev1beta1.Provider{
+				Workers: []gardencorev1beta1.Worker{{Name: "a", Zones: []string{"z2", "z1"}}},
+			},
+		},
+	}
+}
+
+func deploymentReplicas(t *testing.T, out *Output) int32 {
+	t.Helper()
+
+	deployment := &appsv1.Deployment{}
+	if err := json.Unmarshal(out.ShootResources["deployment.yaml"], deployment); err != nil {
+		t.Fatalf("failed to decode deployment: %v", err)
+	}
+
+	return *deployment.Spec.Replicas
+}
+
+func TestResources(t *testing.T) {
+	providerConfig := &config.TraefikConfig{Spec: config.TraefikConfigSpec{Replicas: 3}}
+	in := Input{
+		ProviderConfig: providerConfig,
+		Shoot:          newShoot(),
+		Namespace:      "shoot--foo--bar",
+		ImageVector:    extenimagev.ImageVector(),
+	}
+
+	out, err := Resources(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replicas := deploymentReplicas(t, out); replicas != 3 {
+		t.Errorf("expected 3 replicas, got: %d", replicas)
+	}
+	if _, ok := out.SeedResources["dnsrecord.yaml"]; !ok {
+		t.Fatalf("expected an ingress DNS record, got: %v", out.SeedResources)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"# Source: extension-traefik/deployment.yaml\napiVersion: apps/v1\nkind: Deployment\n",
+		"# Source: extension-traefik-ingress-dns/dnsrecord.yaml\n",
+		"name: '*.ingress.bar.foo.example.com'",
+		"type: aws-route53",
+		"name: dnsrecord-bar-external",
+		"- " + DefaultIngressAddress,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected output to contain %q", expected)
+		}
+	}
+}
+
+func TestResources_Hibernation(t *testing.T) {
+	shoot := newShoot()
+	shoot.Spec.Hibernation = &gardencorev1beta1.Hibernation{Enabled: new(true)}
+	in := Input{Shoot: shoot, Namespace: "shoot--foo--bar", ImageVector: extenimagev.ImageVector()}
+
+	out, err := Resources(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replicas := deploymentReplicas(t, out); replicas != 0 {
+		t.Errorf("expected 0 replicas, got: %d", replicas)
+	}
+	if out.SeedResources != nil {
+		t.Errorf("expected no ingress DNS record, got: %v", out.SeedResources)
+	}
+}
+
+func TestResources_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Input
+	}{
+		{
+			name: "missing shoot",
+			in:   Input{Namespace: "shoot--foo--bar"},
+		},
+		{
+			name: "invalid provider config",
+			in: Input{
+				ProviderConfig: &config.TraefikConfig{Spec: config.TraefikConfigSpec{Namespaces: []string{"Invalid_Namespace"}}},
+				Shoot:          newShoot(),
+				Namespace:      "shoot--foo--bar",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.ImageVector = extenimagev.ImageVector()
+			if _, err := Resources(tt.in); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected invalid input error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeShoot(t *testing.T) {
+	tests := []struct {
+		name              string
+		data              string
+		expectedNamespace string
+		expectedErr       bool
+	}{
+		{
+			name: "shoot",
+			data: `apiVersion: core.gardener.cloud/v1beta1
+kind: Shoot
+metadata:
+  name: bar
+  namespace: garden-foo
+`,
+			expectedNamespace: "shoot--foo--bar",
+		},
+		{
+			name: "cluster",
+			data: `apiVersion: extensions.gardener.cloud/v1alpha1
+kind: Cluster
+metadata:
+  name: shoot--foo--bar
+spec:
+  cloudProfile: {}
+  seed: {}
+  shoot:
+    apiVersion: core.gardener.cloud/v1beta1
+    kind: Shoot
+    metadata:
+      name: bar
+      namespace: garden-foo
+`,
+			expectedNamespace: "shoot--foo--bar",
+		},
+		{
+			name: "other kind",
+			data: `apiVersion: extensions.gardener.cloud/v1alpha1
+kind: Extension
+metadata:
+  name: traefik
+`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shoot, namespace, err := DecodeShoot([]byte(tt.data))
+			if tt.expectedErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if shoot.Name != "bar" || namespace != tt.expectedNamespace {
+				t.Errorf("expected shoot bar in namespace %s, got: %s in %s", tt.expectedNamespace, shoot.Name, namespace)
+			}
+		})
+	}
+}
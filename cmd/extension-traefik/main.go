@@ -11,7 +11,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 
+	diffcmd "github.com/gardener/gardener-extension-shoot-traefik/cmd/extension-traefik/internal/diff"
 	managercmd "github.com/gardener/gardener-extension-shoot-traefik/cmd/extension-traefik/internal/manager"
+	nginxcompatcmd "github.com/gardener/gardener-extension-shoot-traefik/cmd/extension-traefik/internal/nginxcompat"
+	rendercmd "github.com/gardener/gardener-extension-shoot-traefik/cmd/extension-traefik/internal/render"
 	webhookcmd "github.com/gardener/gardener-extension-shoot-traefik/cmd/extension-traefik/webhook"
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/version"
 )
@@ -25,6 +28,9 @@ func main() {
 		Commands: []*cli.Command{
 			managercmd.New(),
 			webhookcmd.New(),
+			rendercmd.New(),
+			diffcmd.New(),
+			nginxcompatcmd.New(),
 		},
 	}
 
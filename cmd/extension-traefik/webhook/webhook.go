@@ -21,6 +21,7 @@ import (
 	glogger "github.com/gardener/gardener/pkg/logger"
 	"github.com/go-logr/logr"
 	"github.com/urfave/cli/v3"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/clientcmd"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
@@ -33,9 +34,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	extenimagev "github.com/gardener/gardener-extension-shoot-traefik/imagevector"
 	admissionvalidator "github.com/gardener/gardener-extension-shoot-traefik/pkg/admission/validator"
 	configinstall "github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config/install"
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/mgr"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/operatorconfig"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
 )
 
 // flags stores the webhook flags as provided from the command-line
@@ -65,6 +69,8 @@ type flags struct {
 	webhookConfigOwnerNamespace string
 	gardenerVersion             string
 	selfHostedShootCluster      bool
+	traefikSupportedVersions    []string
+	configFile                  string
 	sourceCluster               cluster.Cluster
 }
 
@@ -328,6 +334,18 @@ func New() *cli.Command {
 				Sources:     cli.EnvVars("SELF_HOSTED_SHOOT_CLUSTER"),
 				Destination: &flags.selfHostedShootCluster,
 			},
+			&cli.StringSliceFlag{
+				Name:        "traefik-supported-version",
+				Usage:       "traefik version offered to shoot owners, defaults to all versions of the image vector",
+				Sources:     cli.EnvVars("TRAEFIK_SUPPORTED_VERSIONS"),
+				Destination: &flags.traefikSupportedVersions,
+			},
+			&cli.StringFlag{
+				Name:        "config",
+				Usage:       "path to the operator configuration file of the extension, which is reloaded on changes",
+				Sources:     cli.EnvVars("CONFIG_FILE"),
+				Destination: &flags.configFile,
+			},
 			&cli.StringFlag{
 				Name:        "webhook-server-host",
 				Usage:       "address on which the webhook server listens on",
@@ -441,12 +459,41 @@ func runWebhookServer(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
+	imageVector := extenimagev.ImageVector()
+	if len(flags.traefikSupportedVersions) > 0 {
+		imageVector, err = traefik.FilterVersions(imageVector, flags.traefikSupportedVersions)
+		if err != nil {
+			return fmt.Errorf("invalid supported traefik versions: %w", err)
+		}
+	}
+	supportedVersions := traefik.SupportedVersions(imageVector)
+	logger.Info("configured supported traefik versions", "versions", supportedVersions)
+
+	// The webhook validates the provider configs against the limits of the
+	// same operator configuration, which is used by the extension.
+	var operatorConfig *operatorconfig.Store
+	if flags.configFile != "" {
+		logger.Info("loading operator configuration", "path", flags.configFile)
+		decoder := serializer.NewCodecFactory(m.GetScheme(), serializer.EnableStrict).UniversalDecoder()
+		cfg, err := operatorconfig.Load(flags.configFile, decoder)
+		if err != nil {
+			return err
+		}
+
+		operatorConfig = operatorconfig.NewStore(cfg)
+		if err := m.Add(operatorconfig.NewWatcher(flags.configFile, decoder, operatorConfig, ctrllog.Log)); err != nil {
+			return fmt.Errorf("failed to setup operator configuration watcher: %w", err)
+		}
+	}
+
 	logger.Info("setting up admission webhooks")
 
 	// Webhooks to be registered
 	webhooks := make([]*extensionswebhook.Webhook, 0)
 	webhookFuncs := []func(m ctrl.Manager) (*extensionswebhook.Webhook, error){
-		admissionvalidator.NewShootValidatorWebhook,
+		func(m ctrl.Manager) (*extensionswebhook.Webhook, error) {
+			return admissionvalidator.NewShootValidatorWebhook(m, supportedVersions, operatorConfig)
+		},
 	}
 
 	for _, webhookFunc := range webhookFuncs {
@@ -10,6 +10,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	extensionsconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
@@ -18,17 +21,20 @@ import (
 	extensionsutil "github.com/gardener/gardener/extensions/pkg/util"
 	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
 	"github.com/gardener/gardener/pkg/utils/imagevector"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/component-base/featuregate"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/metrics"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/operatorconfig"
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
 )
 
@@ -36,6 +42,23 @@ import (
 // with invalid config settings.
 var ErrInvalidActuator = errors.New("invalid actuator")
 
+// errIngressAddressPending is wrapped by the errors, which are returned while
+// Traefik has no address for the ingress DNS record yet.
+var errIngressAddressPending = errors.New("waiting for the ingress address")
+
+// errShootNodesPending is returned, while the shoot has no ready nodes, on
+// which Traefik can be rolled out.
+var errShootNodesPending = errors.New("waiting for ready nodes in the shoot cluster")
+
+// ingressAddressRequeueInterval is the interval, after which the
+// reconciliation is retried while Traefik has no address for the ingress DNS
+// record yet.
+const ingressAddressRequeueInterval = 15 * time.Second
+
+// rolloutRequeueInterval is the interval, after which the reconciliation is
+// retried while Traefik is being rolled out in the shoot cluster.
+const rolloutRequeueInterval = 15 * time.Second
+
 const (
 	// Name is the name of the actuator
 	Name = "traefik"
@@ -48,9 +71,19 @@ const (
 
 // Actuator is an implementation of [extension.Actuator].
 type Actuator struct {
-	client      client.Client
-	decoder     runtime.Decoder
-	imageVector imagevector.ImageVector
+	client         client.Client
+	decoder        runtime.Decoder
+	imageVector    imagevector.ImageVector
+	operatorConfig *operatorconfig.Store
+	gardenClient   client.Client
+	recorder       events.EventRecorder
+	rolloutTimeout time.Duration
+
+	// nginxCheckInterval is the minimum interval between the nginx
+	// annotation compatibility checks of a shoot, whose last checks are
+	// stored by the name of the cluster in nginxChecks.
+	nginxCheckInterval time.Duration
+	nginxChecks        sync.Map
 
 	// The following fields are usually derived from the list of extra Helm
 	// values provided by gardenlet during the deployment of the extension.
@@ -81,6 +114,8 @@ func New(c client.Client, imageVector imagevector.ImageVector, opts ...Option) (
 		client:                c,
 		imageVector:           imageVector,
 		gardenletFeatureGates: make(map[featuregate.Feature]bool),
+		rolloutTimeout:        traefik.DefaultRolloutTimeout,
+		nginxCheckInterval:    DefaultNginxCompatibilityCheckInterval,
 	}
 
 	for _, opt := range opts {
@@ -136,6 +171,81 @@ func WithGardenletFeatures(feats map[featuregate.Feature]bool) Option {
 	return opt
 }
 
+// WithOperatorConfig is an [Option], which configures the [Actuator] with the
+// given [operatorconfig.Store]. The current operator configuration of the
+// store is merged with the provider config of each shoot during
+// reconciliation.
+func WithOperatorConfig(store *operatorconfig.Store) Option {
+	opt := func(a *Actuator) error {
+		a.operatorConfig = store
+
+		return nil
+	}
+
+	return opt
+}
+
+// WithGardenClient is an [Option], which configures the [Actuator] with a
+// client for the garden cluster. The garden client is used to manage the
+// credentials of the secure dashboard in the project namespace of a shoot.
+func WithGardenClient(c client.Client) Option {
+	opt := func(a *Actuator) error {
+		a.gardenClient = c
+
+		return nil
+	}
+
+	return opt
+}
+
+// WithEventRecorder is an [Option], which configures the [Actuator] with the
+// given [events.EventRecorder]. The events of the actuator are recorded for
+// the reconciled extension resources. No events are recorded, if no event
+// recorder is configured.
+func WithEventRecorder(recorder events.EventRecorder) Option {
+	opt := func(a *Actuator) error {
+		a.recorder = recorder
+
+		return nil
+	}
+
+	return opt
+}
+
+// WithRolloutTimeout is an [Option], which configures the progress deadline
+// of the Traefik Deployment in the shoot cluster, after which its rollout
+// fails and Traefik is rolled back. A zero duration disables the
+// verification of the rollout.
+func WithRolloutTimeout(timeout time.Duration) Option {
+	opt := func(a *Actuator) error {
+		if timeout < 0 {
+			return fmt.Errorf("%w: negative rollout timeout %s", ErrInvalidActuator, timeout)
+		}
+		a.rolloutTimeout = timeout
+
+		return nil
+	}
+
+	return opt
+}
+
+// WithNginxCompatibilityCheckInterval is an [Option], which configures the
+// minimum interval between the checks of the nginx annotations of the Ingress
+// resources in a shoot cluster, which uses the KubernetesIngressNGINX
+// provider. A zero interval checks the annotations on every reconciliation.
+func WithNginxCompatibilityCheckInterval(interval time.Duration) Option {
+	opt := func(a *Actuator) error {
+		if interval < 0 {
+			return fmt.Errorf("%w: negative nginx compatibility check interval %s", ErrInvalidActuator, interval)
+		}
+		a.nginxCheckInterval = interval
+
+		return nil
+	}
+
+	return opt
+}
+
 // Name returns the name of the actuator. This name can be used when registering
 // a controller for the actuator.
 func (a *Actuator) Name() string {
@@ -169,15 +279,14 @@ func (a *Actuator) ExtensionClass() extensionsv1alpha1.ExtensionClass {
 //
 // For the Traefik extension, this deploys Traefik ingress controller to the
 // shoot cluster as a replacement for nginx-ingress-controller.
-func (a *Actuator) Reconcile(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) error {
+func (a *Actuator) Reconcile(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
 	// The cluster name is the same as the name of the namespace for our
 	// [extensionsv1alpha1.Extension] resource.
 	clusterName := ex.Namespace
 	start := time.Now()
 
 	defer func() {
-		metrics.ActuatorOperationTotal.WithLabelValues(clusterName, "reconcile").Inc()
-		metrics.ActuatorOperationDurationSeconds.WithLabelValues(clusterName, "reconcile").Set(time.Since(start).Seconds())
+		metrics.ObserveOperation("reconcile", start, err)
 	}()
 
 	logger.Info("reconciling traefik extension", "name", ex.Name, "cluster", clusterName)
@@ -194,60 +303,245 @@ func (a *Actuator) Reconcile(ctx context.Context, logger logr.Logger, ex *extens
 	}
 
 	if v1beta1helper.HibernationIsEnabled(cluster.Shoot) {
-		logger.Info("shoot is hibernated, skipping traefik deployment", "cluster", clusterName)
+		logger.Info("shoot is hibernated, scaling down traefik", "cluster", clusterName)
 
-		return nil
+		return a.hibernate(ctx, logger, cluster, ex)
 	}
 
-	traefikConfig := traefik.DefaultConfig()
-	if ex.Spec.ProviderConfig != nil {
-		var cfg config.TraefikConfig
-		if err := runtime.DecodeInto(a.decoder, ex.Spec.ProviderConfig.Raw, &cfg); err != nil {
-			logger.Error(err, "failed to decode provider config, using defaults")
-		} else {
-			// Apply custom configuration
-			if cfg.Spec.Replicas > 0 {
-				traefikConfig.Replicas = cfg.Spec.Replicas
-			}
-			if cfg.Spec.IngressProvider != "" {
-				traefikConfig.IngressProvider = cfg.Spec.IngressProvider
-			}
-			if cfg.Spec.LogLevel != "" {
-				if _, ok := traefik.ValidLogLevels[cfg.Spec.LogLevel]; !ok {
-					return fmt.Errorf("invalid traefik log level %q: must be one of Debug, Info, Warn, Error, Fatal, Panic", cfg.Spec.LogLevel)
-				}
-				traefikConfig.LogLevel = cfg.Spec.LogLevel
-			}
-			traefikConfig.Dashboard = cfg.Spec.Dashboard
-		}
+	spec, rollback, err := a.deployedConfig(ctx, logger, ex)
+	if err != nil {
+		return err
+	}
+
+	// A single client for the shoot cluster is shared by all steps of the
+	// reconciliation, which read from or write to the shoot cluster.
+	_, shootClient, err := extensionsutil.NewClientForShoot(ctx, a.client, clusterName, client.Options{Scheme: traefik.ShootScheme()}, extensionsconfigv1alpha1.RESTOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create shoot client: %w", err)
+	}
+
+	traefikConfig, err := a.shootConfig(ctx, logger, cluster, ex, shootClient, spec)
+	if err != nil {
+		return err
+	}
+
+	if err := a.validateDynamicConfig(ctx, shootClient, traefikConfig); err != nil {
+		return err
 	}
 
 	deployer := traefik.NewDeployer(a.client, logger, traefikConfig, a.imageVector)
+	if err := a.migrateCRDStorageVersions(ctx, clusterName, shootClient, deployer); err != nil {
+		return err
+	}
+
 	if err := deployer.Deploy(ctx, clusterName); err != nil {
+		metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonDeploy).Inc()
+		a.recordEvent(ex, corev1.EventTypeWarning, EventReasonDeployFailed, actionDeploy, "Failed to deploy Traefik: %v", err)
+
 		return fmt.Errorf("failed to deploy traefik: %w", err)
 	}
 
+	if err := a.verifyRollout(ctx, logger, cluster, ex, shootClient, deployer, spec, rollback != nil); err != nil {
+		var requeueErr *reconcilerutils.RequeueAfterError
+		if errors.As(err, &requeueErr) {
+			return err
+		}
+		metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonDeploy).Inc()
+		a.recordEvent(ex, corev1.EventTypeWarning, EventReasonDeployFailed, actionDeploy, "Failed to roll out Traefik: %v", err)
+
+		return err
+	}
+	a.recordEvent(ex, corev1.EventTypeNormal, EventReasonDeployed, actionDeploy, "Deployed Traefik to the shoot cluster")
+	metrics.SetManagedShoot(clusterName, string(traefikConfig.IngressProvider), a.traefikVersion(traefikConfig))
+
+	// The compatibility report only informs the shoot owner, so failing to
+	// publish it does not fail the reconciliation.
+	if err := a.reconcileNginxCompatibility(ctx, logger, ex, shootClient, deployer, traefikConfig); err != nil {
+		logger.Error(err, "failed to check the nginx annotation compatibility", "cluster", clusterName)
+	}
+
 	// Deploy the DNSRecord for the Traefik ingress wildcard domain via a seed ManagedResource.
-	if err := a.reconcileDNSRecord(ctx, logger, cluster, clusterName, deployer); err != nil {
+	if err := a.reconcileDNSRecord(ctx, logger, cluster, ex, shootClient, deployer, traefikConfig); err != nil {
+		if errors.Is(err, errIngressAddressPending) {
+			// Without nodes, neither a LoadBalancer address nor a node IP
+			// becomes available. The shoot is not blocked in this case, and
+			// the address is picked up with a later reconciliation.
+			if ready, nodesErr := traefik.HasReadyNodes(ctx, shootClient); nodesErr == nil && !ready {
+				logger.Info("shoot has no ready nodes yet, skipping ingress DNS record", "cluster", clusterName)
+
+				return nil
+			}
+
+			// Waiting for the address is expected after the first deployment
+			// of Traefik, so the reconciliation is requeued after a fixed
+			// interval instead of an exponential backoff, and the controller
+			// reports the last operation as processing.
+			metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonLBPending).Inc()
+
+			return &reconcilerutils.RequeueAfterError{Cause: err, RequeueAfter: ingressAddressRequeueInterval}
+		}
+		metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonDNS).Inc()
+
 		return err
 	}
 
+	if rollback != nil {
+		return fmt.Errorf("traefik runs the last known good configuration, until the failed provider config is changed: %s", rollback.Reason)
+	}
+
 	logger.Info("successfully reconciled traefik extension", "cluster", clusterName)
 
 	return nil
 }
 
-// reconcileDNSRecord reads the Traefik LoadBalancer address from the shoot
+// deployedConfig returns the provider config spec, which is deployed for the
+// given extension. This is the spec of the last known good configuration
+// together with the reported rollback, if the provider config of the
+// extension was rolled back, see [Actuator.rolledBackConfig], and the spec of
+// the provider config otherwise.
+func (a *Actuator) deployedConfig(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) (*config.TraefikConfigSpec, *config.RollbackStatus, error) {
+	spec := a.providerConfigSpec(logger, ex)
+	lastKnownGood, rollback, err := a.rolledBackConfig(ctx, ex, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rollback == nil {
+		return spec, nil, nil
+	}
+
+	logger.Info("keeping the last known good configuration of traefik, until the failed provider config is changed", "cluster", ex.Namespace)
+
+	return lastKnownGood, rollback, nil
+}
+
+// traefikConfig returns the Traefik configuration of the given provider config
+// spec by merging the operator configuration with the spec. The configuration
+// is completed with the settings derived from the shoot, see
+// [traefik.Config.ApplyShoot], and the secure dashboard.
+func (a *Actuator) traefikConfig(ctx context.Context, logger logr.Logger, cluster *extensionscontroller.Cluster, ex *extensionsv1alpha1.Extension, spec *config.TraefikConfigSpec) (traefik.Config, error) {
+	traefikConfig, err := traefik.NewConfig(a.operatorConfig.Get(), spec)
+	if err != nil {
+		metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonDecode).Inc()
+
+		return traefik.Config{}, err
+	}
+	traefikConfig.ProgressDeadline = a.rolloutTimeout
+
+	if traefikConfig.Version != "" {
+		// The admission webhook rejects unknown versions, but a version
+		// may have been removed from the offered versions since.
+		if _, err := traefik.FindImage(a.imageVector, traefikConfig.Version); err != nil {
+			logger.Error(err, "requested traefik version is not offered, using default version", "version", traefikConfig.Version)
+			a.recordEvent(ex, corev1.EventTypeWarning, EventReasonConfigFallback, actionDecodeConfig,
+				"Traefik version %s is not offered, using the default version", traefikConfig.Version)
+			traefikConfig.Version = ""
+		}
+	}
+
+	traefikConfig.ApplyShoot(cluster.Shoot)
+	if err := a.reconcileSecureDashboard(ctx, cluster, &traefikConfig); err != nil {
+		return traefik.Config{}, err
+	}
+
+	return traefikConfig, nil
+}
+
+// shootConfig returns the Traefik configuration of the given provider config
+// spec, see [Actuator.traefikConfig], together with the backends discovered in
+// the shoot cluster, if the network policies of Traefik are restricted to
+// them.
+func (a *Actuator) shootConfig(ctx context.Context, logger logr.Logger, cluster *extensionscontroller.Cluster, ex *extensionsv1alpha1.Extension, shootClient client.Client, spec *config.TraefikConfigSpec) (traefik.Config, error) {
+	traefikConfig, err := a.traefikConfig(ctx, logger, cluster, ex, spec)
+	if err != nil {
+		return traefik.Config{}, err
+	}
+
+	if traefikConfig.NetworkPolicyMode == config.NetworkPolicyModeRestricted && traefikConfig.BackendNamespaceSelector == nil {
+		backends, err := a.discoverBackends(ctx, shootClient, traefikConfig)
+		if err != nil {
+			return traefik.Config{}, err
+		}
+		traefikConfig.Backends = backends
+	}
+
+	return traefikConfig, nil
+}
+
+// traefikVersion returns the Traefik version, which is deployed with the given
+// configuration.
+func (a *Actuator) traefikVersion(traefikConfig traefik.Config) string {
+	img, err := traefik.FindImage(a.imageVector, traefikConfig.Version)
+	if err != nil || img.Version == nil {
+		return traefikConfig.Version
+	}
+
+	return *img.Version
+}
+
+// providerConfigSpec decodes the provider config of the given extension. It
+// returns nil, if the shoot does not specify a provider config or the provider
+// config cannot be decoded.
+func (a *Actuator) providerConfigSpec(logger logr.Logger, ex *extensionsv1alpha1.Extension) *config.TraefikConfigSpec {
+	if ex.Spec.ProviderConfig == nil {
+		return nil
+	}
+
+	var cfg config.TraefikConfig
+	if err := runtime.DecodeInto(a.decoder, ex.Spec.ProviderConfig.Raw, &cfg); err != nil {
+		logger.Error(err, "failed to decode provider config, using defaults")
+		metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonDecode).Inc()
+		a.recordEvent(ex, corev1.EventTypeWarning, EventReasonConfigFallback, actionDecodeConfig, "Failed to decode the provider config, using the defaults: %v", err)
+
+		return nil
+	}
+
+	return &cfg.Spec
+}
+
+// discoverBackends discovers the backends of Traefik in the shoot cluster,
+// which are allowed as egress targets in the Restricted NetworkPolicy mode.
+func (a *Actuator) discoverBackends(ctx context.Context, shootClient client.Client, traefikConfig traefik.Config) ([]traefik.Backend, error) {
+	backends, err := traefik.DiscoverBackends(ctx, shootClient, traefikConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover traefik backends: %w", err)
+	}
+
+	return backends, nil
+}
+
+// migrateCRDStorageVersions migrates the objects of Traefik CRDs in the shoot
+// cluster, whose previously deployed versions are dropped by the CRDs embedded
+// in the extension. The shoot cluster is only contacted, if such a CRD exists.
+func (a *Actuator) migrateCRDStorageVersions(ctx context.Context, clusterName string, shootClient client.Client, deployer *traefik.Deployer) error {
+	dropped, err := deployer.DroppedCRDVersions(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to check traefik CRD versions: %w", err)
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	if err := deployer.MigrateCRDStorageVersions(ctx, shootClient, dropped); err != nil {
+		return fmt.Errorf("failed to migrate traefik CRD storage versions: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileDNSRecord reads the ingress addresses of Traefik from the shoot
 // cluster and creates/updates the seed-class ManagedResource containing the
-// DNSRecord for the wildcard ingress domain.
-func (a *Actuator) reconcileDNSRecord(ctx context.Context, logger logr.Logger, cluster *extensionscontroller.Cluster, clusterName string, deployer *traefik.Deployer) error {
+// DNSRecord for the wildcard ingress domain. A previously deployed DNSRecord
+// is deleted, if the shoot no longer has a DNS domain or an external
+// DNSRecord.
+func (a *Actuator) reconcileDNSRecord(ctx context.Context, logger logr.Logger, cluster *extensionscontroller.Cluster, ex *extensionsv1alpha1.Extension, shootClient client.Client, deployer *traefik.Deployer, traefikConfig traefik.Config) error {
+	clusterName := ex.Namespace
 	shoot := cluster.Shoot
 
 	// Skip DNS record creation when no DNS domain is configured for the shoot.
-	if shoot.Spec.DNS == nil || shoot.Spec.DNS.Domain == nil {
+	if !traefikConfig.DNSRecordEnabled(shoot) {
 		logger.Info("shoot has no DNS domain configured, skipping ingress DNS record", "cluster", clusterName)
 
-		return nil
+		return a.deleteDNSRecord(ctx, logger, ex, deployer, "the shoot has no DNS domain")
 	}
 
 	// Reuse the provider type and credentials secret from the external DNSRecord
@@ -258,31 +552,92 @@ func (a *Actuator) reconcileDNSRecord(ctx context.Context, logger logr.Logger, c
 		return fmt.Errorf("failed to look up external DNSRecord for shoot: %w", err)
 	}
 	if ref == nil {
-		logger.Info("external DNSRecord not yet available for shoot, skipping ingress DNS record", "cluster", clusterName)
+		logger.Info("external DNSRecord not available for shoot, skipping ingress DNS record", "cluster", clusterName)
 
-		return nil
+		return a.deleteDNSRecord(ctx, logger, ex, deployer, "the external DNS record of the shoot does not exist")
 	}
 
-	// Build a client for the shoot cluster to read the Traefik Service.
-	_, shootClient, err := extensionsutil.NewClientForShoot(ctx, a.client, clusterName, client.Options{}, extensionsconfigv1alpha1.RESTOptions{})
+	addresses, err := ingressAddresses(ctx, shootClient, traefikConfig)
 	if err != nil {
-		return fmt.Errorf("failed to create shoot client: %w", err)
+		if errors.Is(err, errIngressAddressPending) {
+			a.recordEvent(ex, corev1.EventTypeNormal, EventReasonIngressAddressPending, actionDeployDNSRecord, "Waiting for the ingress DNS record: %v", err)
+		}
+
+		return err
+	}
+
+	current, err := deployer.DeployedDNSRecord(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	dnsName := fmt.Sprintf("*.%s.%s", gardenerutils.IngressPrefix, traefik.ShootDomain(shoot))
+	if err := deployer.DeployDNSRecord(ctx, clusterName, addresses, dnsName, ref.ProviderType, ref.SecretRef); err != nil {
+		return err
+	}
+
+	switch {
+	case current == nil:
+		a.recordEvent(ex, corev1.EventTypeNormal, EventReasonDNSRecordCreated, actionDeployDNSRecord,
+			"Created the ingress DNS record %s pointing to %s", dnsName, strings.Join(addresses, ", "))
+	case current.Spec.Name != dnsName || !slices.Equal(current.Spec.Values, addresses):
+		a.recordEvent(ex, corev1.EventTypeNormal, EventReasonDNSRecordUpdated, actionDeployDNSRecord,
+			"Updated the ingress DNS record %s pointing to %s", dnsName, strings.Join(addresses, ", "))
+	}
+
+	return nil
+}
+
+// deleteDNSRecord deletes the ingress DNS record of the shoot, if it has been
+// deployed, and records an event with the given reason for the deletion.
+func (a *Actuator) deleteDNSRecord(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension, deployer *traefik.Deployer, reason string) error {
+	clusterName := ex.Namespace
+	deployed, err := deployer.DNSRecordDeployed(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	if !deployed {
+		return nil
+	}
+
+	logger.Info("deleting traefik ingress DNS record", "cluster", clusterName, "reason", reason)
+	if err := deployer.DeleteDNSRecord(ctx, clusterName); err != nil {
+		return fmt.Errorf("failed to delete traefik ingress DNS record: %w", err)
+	}
+	a.recordEvent(ex, corev1.EventTypeNormal, EventReasonDNSRecordDeleted, actionDeleteDNSRecord, "Deleted the ingress DNS record, because %s", reason)
+
+	return nil
+}
+
+// ingressAddresses returns the addresses, which the ingress DNS record points
+// to. These are the IPs of the nodes running Traefik in the DaemonSet mode,
+// see [traefik.DiscoverNodeAddresses], and the LoadBalancer address of the
+// Traefik Service otherwise.
+func ingressAddresses(ctx context.Context, shootClient client.Client, traefikConfig traefik.Config) ([]string, error) {
+	if traefikConfig.DaemonSetMode() {
+		addresses, err := traefik.DiscoverNodeAddresses(ctx, shootClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover node addresses: %w", err)
+		}
+		if len(addresses) == 0 {
+			return nil, fmt.Errorf("%w: no IP address of a node running traefik available yet", errIngressAddressPending)
+		}
+
+		return addresses, nil
 	}
 
 	svc := &corev1.Service{}
 	if err := shootClient.Get(ctx, client.ObjectKey{Namespace: traefik.Namespace, Name: traefik.DeploymentName}, svc); err != nil {
-		return fmt.Errorf("failed to get traefik service from shoot: %w", err)
+		return nil, fmt.Errorf("failed to get traefik service from shoot: %w", err)
 	}
 
 	// Determine the LB address – the Service may still be pending.
 	lbAddress := lbAddressFromService(svc)
 	if lbAddress == "" {
-		return errors.New("traefik LoadBalancer address not yet available, will retry")
+		return nil, fmt.Errorf("%w: traefik LoadBalancer address not yet available", errIngressAddressPending)
 	}
 
-	dnsName := fmt.Sprintf("*.%s.%s", gardenerutils.IngressPrefix, *shoot.Spec.DNS.Domain)
-
-	return deployer.DeployDNSRecord(ctx, clusterName, lbAddress, dnsName, ref.ProviderType, ref.SecretRef)
+	return []string{lbAddress}, nil
 }
 
 // dnsRecordRef holds the DNS provider type and credentials secret reference
@@ -330,13 +685,12 @@ func lbAddressFromService(svc *corev1.Service) string {
 // so that resource-manager cleanly removes the Traefik objects from the shoot cluster
 // before the ManagedResource itself is deleted. The seed-side DNSRecord ManagedResource
 // is deleted first so that the DNS extension cleans up the actual DNS record.
-func (a *Actuator) Delete(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) error {
+func (a *Actuator) Delete(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
 	clusterName := ex.Namespace
 	start := time.Now()
 
 	defer func() {
-		metrics.ActuatorOperationTotal.WithLabelValues(clusterName, "delete").Inc()
-		metrics.ActuatorOperationDurationSeconds.WithLabelValues(clusterName, "delete").Set(time.Since(start).Seconds())
+		metrics.ObserveOperation("delete", start, err)
 	}()
 
 	logger.Info("deleting traefik resources managed by extension", "cluster", clusterName)
@@ -346,15 +700,31 @@ func (a *Actuator) Delete(ctx context.Context, logger logr.Logger, ex *extension
 	// First delete the DNSRecord ManagedResource from the seed and wait for the
 	// DNS extension to clean up the actual DNS record.
 	if err := deployer.DeleteDNSRecord(ctx, clusterName); err != nil {
+		metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonDNS).Inc()
+
 		return fmt.Errorf("failed to delete traefik ingress DNS record: %w", err)
 	}
 
-	// Delete the shoot ManagedResource. The shoot kube-apiserver is still
+	// Delete the shoot ManagedResources. The shoot kube-apiserver is still
 	// running at this point (delete: BeforeKubeAPIServer), so resource-manager
 	// can cleanly remove Traefik from the shoot cluster.
+	if err := a.deleteNginxCompatibility(ctx, clusterName, deployer, false); err != nil {
+		return err
+	}
 	if err := deployer.Delete(ctx, clusterName); err != nil {
+		metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonDeploy).Inc()
+
 		return fmt.Errorf("failed to delete traefik: %w", err)
 	}
+	metrics.DeleteManagedShoot(clusterName)
+
+	if err := a.deleteDashboardCredentials(ctx, clusterName); err != nil {
+		return err
+	}
+
+	if err := traefik.DeleteLastKnownGoodConfig(ctx, a.client, clusterName); err != nil {
+		return err
+	}
 
 	logger.Info("successfully deleted traefik resources", "cluster", clusterName)
 
@@ -369,13 +739,12 @@ func (a *Actuator) Delete(ctx context.Context, logger logr.Logger, ex *extension
 // keepObjects=true on the shoot ManagedResource to let resource-manager remove
 // its finalizer immediately without trying to reach the shoot. The seed DNS
 // record is still cleaned up normally.
-func (a *Actuator) ForceDelete(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) error {
+func (a *Actuator) ForceDelete(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
 	clusterName := ex.Namespace
 	start := time.Now()
 
 	defer func() {
-		metrics.ActuatorOperationTotal.WithLabelValues(clusterName, "force_delete").Inc()
-		metrics.ActuatorOperationDurationSeconds.WithLabelValues(clusterName, "force_delete").Set(time.Since(start).Seconds())
+		metrics.ObserveOperation("force_delete", start, err)
 	}()
 
 	logger.Info("shoot has been force-deleted, deleting traefik resources", "cluster", clusterName)
@@ -387,23 +756,26 @@ func (a *Actuator) ForceDelete(ctx context.Context, logger logr.Logger, ex *exte
 		logger.Error(err, "failed to delete traefik ingress DNS record during force-delete", "cluster", clusterName)
 	}
 
-	// Delete shoot ManagedResource keeping objects because the shoot
+	// Delete shoot ManagedResources keeping objects because the shoot
 	// API server is unreachable during force-delete.
+	if err := a.deleteNginxCompatibility(ctx, clusterName, deployer, true); err != nil {
+		return err
+	}
 	if err := deployer.DeleteKeepingObjects(ctx, clusterName); err != nil {
 		return fmt.Errorf("failed to force-delete traefik: %w", err)
 	}
+	metrics.DeleteManagedShoot(clusterName)
 
-	return nil
+	return traefik.DeleteLastKnownGoodConfig(ctx, a.client, clusterName)
 }
 
 // Restore restores the resources managed by the extension [Actuator]. This
 // method implements the [extension.Actuator] interface.
-func (a *Actuator) Restore(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) error {
+func (a *Actuator) Restore(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
 	start := time.Now()
 
 	defer func() {
-		metrics.ActuatorOperationTotal.WithLabelValues(ex.Namespace, "restore").Inc()
-		metrics.ActuatorOperationDurationSeconds.WithLabelValues(ex.Namespace, "restore").Set(time.Since(start).Seconds())
+		metrics.ObserveOperation("restore", start, err)
 	}()
 
 	return a.Reconcile(ctx, logger, ex)
@@ -416,13 +788,12 @@ func (a *Actuator) Restore(ctx context.Context, logger logr.Logger, ex *extensio
 // During migration, shoot objects must be preserved (keepObjects=true) while
 // the seed-side ManagedResources are deleted, so that the new seed can
 // re-create them.
-func (a *Actuator) Migrate(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) error {
+func (a *Actuator) Migrate(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension) (err error) {
 	clusterName := ex.Namespace
 	start := time.Now()
 
 	defer func() {
-		metrics.ActuatorOperationTotal.WithLabelValues(clusterName, "migrate").Inc()
-		metrics.ActuatorOperationDurationSeconds.WithLabelValues(clusterName, "migrate").Set(time.Since(start).Seconds())
+		metrics.ObserveOperation("migrate", start, err)
 	}()
 
 	logger.Info("migrating traefik extension, cleaning up control-plane resources", "cluster", clusterName)
@@ -431,14 +802,31 @@ func (a *Actuator) Migrate(ctx context.Context, logger logr.Logger, ex *extensio
 
 	// Delete the seed DNSRecord ManagedResource; the new seed will recreate it.
 	if err := deployer.DeleteDNSRecord(ctx, clusterName); err != nil {
+		metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonDNS).Inc()
+
 		return fmt.Errorf("failed to delete traefik ingress DNS record during migrate: %w", err)
 	}
 
 	// Keep shoot objects alive (traefik keeps running in the shoot) and only
-	// remove the ManagedResource from the old seed.
+	// remove the ManagedResources from the old seed.
+	if err := a.deleteNginxCompatibility(ctx, clusterName, deployer, true); err != nil {
+		return err
+	}
 	if err := deployer.DeleteKeepingObjects(ctx, clusterName); err != nil {
 		return fmt.Errorf("failed to delete traefik managed resource during migrate: %w", err)
 	}
+	metrics.DeleteManagedShoot(clusterName)
+
+	// The last known good configuration is stored again on the new seed
+	// after the first successful rollout. A rollback reported in the status
+	// is removed as well, because the new seed has no last known good
+	// configuration to keep deployed.
+	if err := traefik.DeleteLastKnownGoodConfig(ctx, a.client, clusterName); err != nil {
+		return err
+	}
+	if err := a.updateRollbackStatus(ctx, ex, nil); err != nil {
+		return err
+	}
 
 	logger.Info("successfully migrated traefik extension", "cluster", clusterName)
 
@@ -6,22 +6,32 @@ package actuator_test
 
 import (
 	"encoding/json"
+	"errors"
+	"time"
 
 	corev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/component-base/featuregate"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
 
 	"github.com/gardener/gardener-extension-shoot-traefik/imagevector"
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/actuator"
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
 )
 
 var _ = Describe("Actuator", Ordered, func() {
@@ -94,6 +104,8 @@ var _ = Describe("Actuator", Ordered, func() {
 			actuator.WithGardenerVersion("1.0.0"),
 			actuator.WithDecoder(decoder),
 			actuator.WithGardenletFeatures(featureGates),
+			// There is no shoot cluster to wait for the rollout of Traefik.
+			actuator.WithRolloutTimeout(0),
 		}
 
 		// Serialize our test objects, so we can later re-use them.
@@ -109,6 +121,16 @@ var _ = Describe("Actuator", Ordered, func() {
 
 		Expect(k8sClient.Create(ctx, projectNamespace)).To(Succeed())
 		Expect(k8sClient.Create(ctx, shootNamespace)).To(Succeed())
+
+		// The test environment acts as the shoot cluster.
+		user, err := testEnv.AddUser(envtest.User{Name: "gardener", Groups: []string{"system:masters"}}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		kubeconfig, err := user.KubeConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: shootNamespace.Name, Name: "gardener"},
+			Data:       map[string][]byte{"kubeconfig": kubeconfig},
+		})).To(Succeed())
 	})
 
 	BeforeEach(func() {
@@ -149,6 +171,34 @@ var _ = Describe("Actuator", Ordered, func() {
 		Expect(k8sClient.Delete(ctx, cluster)).To(Succeed())
 	})
 
+	// deployDNSRecord creates the seed ManagedResource of the ingress DNS
+	// record, as it exists for a shoot with a DNS domain.
+	deployDNSRecord := func() {
+		deployer := traefik.NewDeployer(k8sClient, logger, traefik.DefaultConfig(), nil)
+		Expect(deployer.DeployDNSRecord(ctx, shootNamespace.Name, []string{"10.0.0.1"}, "*.ingress.local.example.com", "local",
+			corev1.SecretReference{Name: "dns", Namespace: shootNamespace.Name})).To(Succeed())
+		DeferCleanup(func() {
+			Expect(deployer.DeleteDNSRecord(ctx, shootNamespace.Name)).To(Succeed())
+		})
+	}
+
+	// recordedEvents returns the events, which have been recorded so far.
+	recordedEvents := func(recorder *events.FakeRecorder) []string {
+		var recorded []string
+		for {
+			select {
+			case event := <-recorder.Events:
+				recorded = append(recorded, event)
+			default:
+				return recorded
+			}
+		}
+	}
+
+	seedManagedResource := func() error {
+		return k8sClient.Get(ctx, client.ObjectKey{Namespace: shootNamespace.Name, Name: traefik.SeedManagedResourceName}, &resourcesv1alpha1.ManagedResource{})
+	}
+
 	It("should successfully create an actuator", func() {
 		act, err := actuator.New(k8sClient, imagevector.ImageVector(), actuatorOpts...)
 
@@ -195,6 +245,29 @@ var _ = Describe("Actuator", Ordered, func() {
 		Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
 	})
 
+	It("should delete the ingress DNS record of a shoot without DNS domain", func() {
+		shootWithPurpose := shoot.DeepCopy()
+		shootWithPurpose.Spec.Purpose = ptr.To(corev1beta1.ShootPurposeEvaluation)
+		shootWithPurposeData, err := json.Marshal(shootWithPurpose)
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster.Spec.Shoot.Raw = shootWithPurposeData
+		Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+
+		extResource.Spec.ProviderConfig = &runtime.RawExtension{
+			Raw: providerConfigData,
+		}
+		deployDNSRecord()
+
+		recorder := events.NewFakeRecorder(10)
+		act, err := actuator.New(k8sClient, imagevector.ImageVector(), append(actuatorOpts, actuator.WithEventRecorder(recorder))...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+
+		Expect(apierrors.IsNotFound(seedManagedResource())).To(BeTrue())
+		Expect(recordedEvents(recorder)).To(ContainElement("Normal " + actuator.EventReasonDNSRecordDeleted + " Deleted the ingress DNS record, because the shoot has no DNS domain"))
+	})
+
 	It("should succeed on Delete", func() {
 		act, err := actuator.New(k8sClient, imagevector.ImageVector(), actuatorOpts...)
 		Expect(err).NotTo(HaveOccurred())
@@ -390,4 +463,495 @@ var _ = Describe("Actuator", Ordered, func() {
 			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
 		})
 	})
+
+	Context("Hibernation", func() {
+		setHibernation := func(enabled bool) {
+			shootWithHibernation := shoot.DeepCopy()
+			shootWithHibernation.Spec.Purpose = ptr.To(corev1beta1.ShootPurposeEvaluation)
+			shootWithHibernation.Spec.Hibernation = &corev1beta1.Hibernation{Enabled: ptr.To(enabled)}
+			shootWithHibernationData, err := json.Marshal(shootWithHibernation)
+			Expect(err).NotTo(HaveOccurred())
+
+			cluster.Spec.Shoot.Raw = shootWithHibernationData
+			Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+		}
+
+		setProviderConfig := func(hibernation *config.Hibernation) {
+			cfg := config.TraefikConfig{
+				Spec: config.TraefikConfigSpec{
+					Replicas:    2,
+					Hibernation: hibernation,
+				},
+			}
+			cfgData, err := json.Marshal(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			extResource.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: cfgData,
+			}
+		}
+
+		deployedReplicas := func() int32 {
+			resources, err := traefik.NewDeployer(k8sClient, logger, traefik.DefaultConfig(), nil).DeployedResources(ctx, shootNamespace.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(HaveKey("deployment.yaml"))
+
+			deployment := &appsv1.Deployment{}
+			Expect(json.Unmarshal(resources["deployment.yaml"], deployment)).To(Succeed())
+			Expect(deployment.Spec.Replicas).NotTo(BeNil())
+
+			return *deployment.Spec.Replicas
+		}
+
+		It("should scale down traefik and delete the ingress DNS record", func() {
+			setHibernation(true)
+			setProviderConfig(nil)
+			deployDNSRecord()
+
+			recorder := events.NewFakeRecorder(10)
+			act, err := actuator.New(k8sClient, imagevector.ImageVector(), append(actuatorOpts, actuator.WithEventRecorder(recorder))...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+
+			Expect(deployedReplicas()).To(BeZero())
+			Expect(apierrors.IsNotFound(seedManagedResource())).To(BeTrue())
+			Expect(recordedEvents(recorder)).To(ConsistOf(
+				"Normal "+actuator.EventReasonDeployed+" Scaled down Traefik, because the shoot is hibernated",
+				"Normal "+actuator.EventReasonDNSRecordDeleted+" Deleted the ingress DNS record, because the shoot is hibernated",
+			))
+		})
+
+		It("should scale down traefik and keep the ingress DNS record with the Keep policy", func() {
+			setHibernation(true)
+			setProviderConfig(&config.Hibernation{DNSRecordPolicy: config.DNSRecordPolicyKeep})
+			deployDNSRecord()
+
+			act, err := actuator.New(k8sClient, imagevector.ImageVector(), actuatorOpts...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+
+			Expect(deployedReplicas()).To(BeZero())
+			Expect(seedManagedResource()).To(Succeed())
+		})
+
+		It("should scale up traefik again when the shoot is woken up", func() {
+			setProviderConfig(nil)
+			act, err := actuator.New(k8sClient, imagevector.ImageVector(), actuatorOpts...)
+			Expect(err).NotTo(HaveOccurred())
+
+			setHibernation(true)
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+			Expect(deployedReplicas()).To(BeZero())
+
+			setHibernation(false)
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+			Expect(deployedReplicas()).To(Equal(int32(2)))
+		})
+	})
+
+	Context("Events", func() {
+		var recorder *events.FakeRecorder
+
+		BeforeEach(func() {
+			shootWithPurpose := shoot.DeepCopy()
+			shootWithPurpose.Spec.Purpose = ptr.To(corev1beta1.ShootPurposeEvaluation)
+			shootWithPurposeData, err := json.Marshal(shootWithPurpose)
+			Expect(err).NotTo(HaveOccurred())
+
+			cluster.Spec.Shoot.Raw = shootWithPurposeData
+			Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+
+			recorder = events.NewFakeRecorder(10)
+		})
+
+		It("should record an event when traefik is deployed", func() {
+			extResource.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: providerConfigData,
+			}
+
+			act, err := actuator.New(k8sClient, imagevector.ImageVector(), append(actuatorOpts, actuator.WithEventRecorder(recorder))...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+
+			Expect(recordedEvents(recorder)).To(ConsistOf(
+				"Normal " + actuator.EventReasonDeployed + " Deployed Traefik to the shoot cluster",
+			))
+		})
+
+		It("should record an event when the provider config cannot be decoded", func() {
+			extResource.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: []byte(`{"invalid json`),
+			}
+
+			act, err := actuator.New(k8sClient, imagevector.ImageVector(), append(actuatorOpts, actuator.WithEventRecorder(recorder))...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+
+			Expect(recordedEvents(recorder)).To(ConsistOf(
+				HavePrefix("Warning "+actuator.EventReasonConfigFallback+" Failed to decode the provider config, using the defaults: "),
+				"Normal "+actuator.EventReasonDeployed+" Deployed Traefik to the shoot cluster",
+			))
+		})
+
+		It("should record an event when the requested version is not offered", func() {
+			cfg := config.TraefikConfig{
+				Spec: config.TraefikConfigSpec{
+					Version: "v9.9.9",
+				},
+			}
+			cfgData, err := json.Marshal(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			extResource.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: cfgData,
+			}
+
+			act, err := actuator.New(k8sClient, imagevector.ImageVector(), append(actuatorOpts, actuator.WithEventRecorder(recorder))...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+
+			Expect(recordedEvents(recorder)).To(ConsistOf(
+				"Warning "+actuator.EventReasonConfigFallback+" Traefik version v9.9.9 is not offered, using the default version",
+				"Normal "+actuator.EventReasonDeployed+" Deployed Traefik to the shoot cluster",
+			))
+		})
+	})
+
+	Context("Secure Dashboard", func() {
+		var credentialsKey client.ObjectKey
+
+		BeforeEach(func() {
+			shootWithDomain := shoot.DeepCopy()
+			shootWithDomain.UID = "8c4a1f0e-3b52-4d1c-9a4e-0d5b6f7e8a91"
+			shootWithDomain.Spec.Purpose = ptr.To(corev1beta1.ShootPurposeEvaluation)
+			shootWithDomain.Spec.DNS = &corev1beta1.DNS{Domain: ptr.To("local.example.com")}
+			shootWithDomainData, err := json.Marshal(shootWithDomain)
+			Expect(err).NotTo(HaveOccurred())
+
+			cluster.Spec.Shoot.Raw = shootWithDomainData
+			Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+
+			cfg := config.TraefikConfig{
+				Spec: config.TraefikConfigSpec{
+					Dashboard:       true,
+					SecureDashboard: &config.SecureDashboard{Auth: config.DashboardAuthBasicAuth},
+				},
+			}
+			cfgData, err := json.Marshal(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			extResource.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: cfgData,
+			}
+
+			credentialsKey = client.ObjectKey{Namespace: projectNamespace.Name, Name: shoot.Name + "." + actuator.DashboardCredentialsSuffix}
+			DeferCleanup(func() {
+				secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: credentialsKey.Namespace, Name: credentialsKey.Name}}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+			})
+		})
+
+		credentials := func() *corev1.Secret {
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, credentialsKey, secret)).To(Succeed())
+
+			return secret
+		}
+
+		deployedUsers := func() string {
+			resources, err := traefik.NewDeployer(k8sClient, logger, traefik.DefaultConfig(), nil).DeployedResources(ctx, shootNamespace.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(HaveKey("dashboard-auth-secret.yaml"))
+
+			secret := &corev1.Secret{}
+			Expect(json.Unmarshal(resources["dashboard-auth-secret.yaml"], secret)).To(Succeed())
+
+			return string(secret.Data["users"])
+		}
+
+		It("should generate stable credentials in the project namespace", func() {
+			act, err := actuator.New(k8sClient, imagevector.ImageVector(), append(actuatorOpts, actuator.WithGardenClient(k8sClient))...)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+			generated := credentials()
+			Expect(generated.Data).To(HaveKeyWithValue("username", []byte(traefik.DashboardUsername)))
+			Expect(generated.Data).To(HaveKeyWithValue("url", []byte("https://"+traefik.DashboardHost("local.example.com"))))
+			Expect(generated.OwnerReferences).To(ConsistOf(HaveField("Name", shoot.Name)))
+			Expect(traefik.DashboardCredentials{
+				Username: string(generated.Data["username"]),
+				Password: string(generated.Data["password"]),
+				Users:    string(generated.Data["users"]),
+			}.Valid()).To(BeTrue())
+			Expect(deployedUsers()).To(Equal(string(generated.Data["users"])))
+
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+			Expect(credentials().Data).To(Equal(generated.Data))
+			Expect(deployedUsers()).To(Equal(string(generated.Data["users"])))
+		})
+
+		It("should reuse existing credentials", func() {
+			existing, err := traefik.NewDashboardCredentials("operator", "secret-password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: credentialsKey.Namespace, Name: credentialsKey.Name},
+				Data: map[string][]byte{
+					"username": []byte(existing.Username),
+					"password": []byte(existing.Password),
+					"users":    []byte(existing.Users),
+				},
+			})).To(Succeed())
+
+			act, err := actuator.New(k8sClient, imagevector.ImageVector(), append(actuatorOpts, actuator.WithGardenClient(k8sClient))...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+
+			// The missing URL is added, but the credentials are kept.
+			Expect(credentials().Data).To(Equal(map[string][]byte{
+				"username": []byte(existing.Username),
+				"password": []byte(existing.Password),
+				"users":    []byte(existing.Users),
+				"url":      []byte("https://" + traefik.DashboardHost("local.example.com")),
+			}))
+			Expect(deployedUsers()).To(Equal(existing.Users))
+		})
+
+		It("should delete the credentials on Delete", func() {
+			act, err := actuator.New(k8sClient, imagevector.ImageVector(), append(actuatorOpts, actuator.WithGardenClient(k8sClient))...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+			credentials()
+
+			Expect(act.Delete(ctx, logger, extResource)).To(Succeed())
+			Expect(apierrors.IsNotFound(k8sClient.Get(ctx, credentialsKey, &corev1.Secret{}))).To(BeTrue())
+		})
+
+		It("should fail without access to the garden cluster", func() {
+			act, err := actuator.New(k8sClient, imagevector.ImageVector(), actuatorOpts...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(act.Reconcile(ctx, logger, extResource)).To(MatchError(ContainSubstring("requires access to the garden cluster")))
+		})
+	})
+
+	Context("Rollback", func() {
+		var (
+			recorder      *events.FakeRecorder
+			act           *actuator.Actuator
+			traefikLabels = map[string]string{
+				"app.kubernetes.io/name":     "traefik",
+				"app.kubernetes.io/instance": "traefik",
+			}
+		)
+
+		// setProviderConfig sets the provider config of the extension to the
+		// given number of replicas.
+		setProviderConfig := func(replicas int32) {
+			cfgData, err := json.Marshal(config.TraefikConfig{Spec: config.TraefikConfigSpec{Replicas: replicas}})
+			Expect(err).NotTo(HaveOccurred())
+
+			patch := client.MergeFrom(extResource.DeepCopy())
+			extResource.Spec.ProviderConfig = &runtime.RawExtension{Raw: cfgData}
+			Expect(k8sClient.Patch(ctx, extResource, patch)).To(Succeed())
+		}
+
+		create := func(obj client.Object) {
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj, client.GracePeriodSeconds(0)))).To(Succeed())
+			})
+		}
+
+		// createShootObject creates the given object in the shoot cluster,
+		// which is the test environment itself, and updates its status.
+		createShootObject := func(obj client.Object) {
+			withStatus := obj.DeepCopyObject().(client.Object)
+			create(obj)
+
+			withStatus.SetResourceVersion(obj.GetResourceVersion())
+			Expect(k8sClient.Status().Update(ctx, withStatus)).To(Succeed())
+		}
+
+		// crashingPod creates a crashlooping Traefik pod in the shoot cluster.
+		crashingPod := func() *corev1.Pod {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: traefik.Namespace, Name: "traefik-crashing", Labels: traefikLabels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "traefik", Image: "traefik"}}},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "traefik",
+						Image: "traefik",
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+						},
+					}},
+				},
+			}
+			createShootObject(pod)
+
+			return pod
+		}
+
+		// rollOut marks the ManagedResource of Traefik as applied and healthy
+		// and creates a rolled out Deployment in the shoot cluster.
+		rollOut := func() {
+			mr := &resourcesv1alpha1.ManagedResource{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: shootNamespace.Name, Name: traefik.ManagedResourceName}, mr)).To(Succeed())
+			mr.Status = resourcesv1alpha1.ManagedResourceStatus{
+				ObservedGeneration: mr.Generation,
+				Conditions: []corev1beta1.Condition{
+					{Type: resourcesv1alpha1.ResourcesApplied, Status: corev1beta1.ConditionTrue, LastTransitionTime: metav1.Now(), LastUpdateTime: metav1.Now()},
+					{Type: resourcesv1alpha1.ResourcesHealthy, Status: corev1beta1.ConditionTrue, LastTransitionTime: metav1.Now(), LastUpdateTime: metav1.Now()},
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, mr)).To(Succeed())
+
+			deployment := &appsv1.Deployment{}
+			err := k8sClient.Get(ctx, client.ObjectKey{Namespace: traefik.Namespace, Name: traefik.DeploymentName}, deployment)
+			if err == nil {
+				return
+			}
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			createShootObject(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: traefik.Namespace, Name: traefik.DeploymentName},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To(int32(0)),
+					Selector: &metav1.LabelSelector{MatchLabels: traefikLabels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: traefikLabels},
+						Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "traefik", Image: "traefik"}}},
+					},
+				},
+				Status: appsv1.DeploymentStatus{
+					ObservedGeneration: 1,
+					Conditions: []appsv1.DeploymentCondition{
+						{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+						{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+					},
+				},
+			})
+		}
+
+		deployedReplicas := func() int32 {
+			resources, err := traefik.NewDeployer(k8sClient, logger, traefik.DefaultConfig(), nil).DeployedResources(ctx, shootNamespace.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(HaveKey("deployment.yaml"))
+
+			deployment := &appsv1.Deployment{}
+			Expect(json.Unmarshal(resources["deployment.yaml"], deployment)).To(Succeed())
+			Expect(deployment.Spec.Replicas).NotTo(BeNil())
+
+			return *deployment.Spec.Replicas
+		}
+
+		rollbackStatus := func() *config.RollbackStatus {
+			ex := &extensionsv1alpha1.Extension{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(extResource), ex)).To(Succeed())
+			if ex.Status.ProviderStatus == nil {
+				return nil
+			}
+
+			status := &config.TraefikStatus{}
+			Expect(runtime.DecodeInto(decoder, ex.Status.ProviderStatus.Raw, status)).To(Succeed())
+
+			return status.Rollback
+		}
+
+		BeforeEach(func() {
+			createShootObject(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				},
+			})
+
+			Expect(k8sClient.Create(ctx, extResource)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, extResource)).To(Succeed())
+				Expect(traefik.DeleteLastKnownGoodConfig(ctx, k8sClient, shootNamespace.Name)).To(Succeed())
+			})
+
+			Expect(traefik.SaveLastKnownGoodConfig(ctx, k8sClient, shootNamespace.Name, &config.TraefikConfigSpec{Replicas: 2})).To(Succeed())
+
+			var err error
+			recorder = events.NewFakeRecorder(10)
+			act, err = actuator.New(k8sClient, imagevector.ImageVector(),
+				append(actuatorOpts, actuator.WithRolloutTimeout(time.Minute), actuator.WithEventRecorder(recorder))...)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should wait for ready nodes to verify the rollout", func() {
+			Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}})).To(Succeed())
+			setProviderConfig(3)
+
+			err := act.Reconcile(ctx, logger, extResource)
+			var requeueErr *reconcilerutils.RequeueAfterError
+			Expect(errors.As(err, &requeueErr)).To(BeTrue(), "expected the verification to be requeued, got: %v", err)
+			Expect(requeueErr.Cause).To(MatchError(ContainSubstring("waiting for ready nodes in the shoot cluster")))
+			Expect(deployedReplicas()).To(Equal(int32(3)))
+			Expect(rollbackStatus()).To(BeNil())
+		})
+
+		It("should roll back a failed rollout and report it in the status", func() {
+			setProviderConfig(3)
+			crashingPod()
+
+			Expect(act.Reconcile(ctx, logger, extResource)).To(MatchError(ContainSubstring("rolled back traefik to the last known good configuration")))
+
+			Expect(deployedReplicas()).To(Equal(int32(2)))
+			rollback := rollbackStatus()
+			Expect(rollback).NotTo(BeNil())
+			Expect(rollback.FailedConfig).To(Equal(&config.TraefikConfigSpec{Replicas: 3}))
+			Expect(rollback.Reason).To(ContainSubstring("traefik pod traefik-crashing is crashlooping"))
+			Expect(recordedEvents(recorder)).To(ContainElement(
+				HavePrefix("Warning " + actuator.EventReasonRolledBack + " Rolled back Traefik to the last known good configuration"),
+			))
+		})
+
+		It("should keep the last known good configuration until the provider config is changed", func() {
+			setProviderConfig(3)
+			pod := crashingPod()
+			Expect(act.Reconcile(ctx, logger, extResource)).To(HaveOccurred())
+			rollback := rollbackStatus()
+			Expect(rollback).NotTo(BeNil())
+
+			// The failed configuration is not retried, after Traefik recovered
+			// with the last known good configuration.
+			Expect(k8sClient.Delete(ctx, pod, client.GracePeriodSeconds(0))).To(Succeed())
+			rollOut()
+			Expect(act.Reconcile(ctx, logger, extResource)).To(MatchError(ContainSubstring("traefik runs the last known good configuration")))
+			Expect(deployedReplicas()).To(Equal(int32(2)))
+			Expect(rollbackStatus()).To(Equal(rollback))
+
+			// A changed provider config is rolled out and removes the rollback
+			// from the status.
+			setProviderConfig(4)
+			err := act.Reconcile(ctx, logger, extResource)
+			var requeueErr *reconcilerutils.RequeueAfterError
+			Expect(errors.As(err, &requeueErr)).To(BeTrue(), "expected the rollout to be in progress, got: %v", err)
+			Expect(deployedReplicas()).To(Equal(int32(4)))
+
+			rollOut()
+			Expect(act.Reconcile(ctx, logger, extResource)).To(Succeed())
+			Expect(rollbackStatus()).To(BeNil())
+			lastKnownGood, found, err := traefik.LastKnownGoodConfig(ctx, k8sClient, shootNamespace.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(lastKnownGood).To(Equal(&config.TraefikConfigSpec{Replicas: 4}))
+		})
+
+		It("should remove the rollback and the last known good configuration on Migrate", func() {
+			setProviderConfig(3)
+			crashingPod()
+			Expect(act.Reconcile(ctx, logger, extResource)).To(HaveOccurred())
+			Expect(rollbackStatus()).NotTo(BeNil())
+
+			Expect(act.Migrate(ctx, logger, extResource)).To(Succeed())
+			Expect(rollbackStatus()).To(BeNil())
+			_, found, err := traefik.LastKnownGoodConfig(ctx, k8sClient, shootNamespace.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
 })
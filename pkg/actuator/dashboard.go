@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package actuator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+const (
+	// DashboardCredentialsSuffix is the suffix of the Secret in the project
+	// namespace of a shoot, which contains the BasicAuth credentials of the
+	// secure dashboard. The name of the Secret is
+	// "<shoot name>.traefik-dashboard".
+	DashboardCredentialsSuffix = "traefik-dashboard"
+
+	// dashboardCredentialsUsernameKey is the data key of the username in the
+	// dashboard credentials Secret.
+	dashboardCredentialsUsernameKey = "username"
+	// dashboardCredentialsPasswordKey is the data key of the password in the
+	// dashboard credentials Secret.
+	dashboardCredentialsPasswordKey = "password"
+	// dashboardCredentialsUsersKey is the data key of the htpasswd entry in
+	// the dashboard credentials Secret.
+	dashboardCredentialsUsersKey = "users"
+	// dashboardCredentialsURLKey is the data key of the dashboard URL in the
+	// dashboard credentials Secret.
+	dashboardCredentialsURLKey = "url"
+)
+
+// reconcileSecureDashboard completes the secure dashboard settings of the
+// given Traefik configuration with the BasicAuth credentials of the shoot.
+// The host of the dashboard is set by [traefik.Config.ApplyShoot] before.
+//
+// Generated credentials are kept, when the secure dashboard is disabled
+// later on. They are deleted together with the extension, or garbage
+// collected together with the shoot.
+func (a *Actuator) reconcileSecureDashboard(ctx context.Context, cluster *extensionscontroller.Cluster, traefikConfig *traefik.Config) error {
+	if !traefikConfig.DashboardCredentialsRequired() {
+		return nil
+	}
+
+	credentials, err := a.dashboardCredentials(ctx, cluster.Shoot, traefikConfig.SecureDashboard.Host)
+	if err != nil {
+		return err
+	}
+	traefikConfig.SecureDashboard.Users = credentials.Users
+
+	return nil
+}
+
+// dashboardCredentials returns the BasicAuth credentials of the secure
+// dashboard, which are stored in the project namespace of the given shoot.
+// New credentials are generated, if the Secret does not exist or contains
+// invalid credentials.
+func (a *Actuator) dashboardCredentials(ctx context.Context, shoot *gardencorev1beta1.Shoot, host string) (traefik.DashboardCredentials, error) {
+	if a.gardenClient == nil {
+		return traefik.DashboardCredentials{}, errors.New("the BasicAuth dashboard requires access to the garden cluster")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gardenerutils.ComputeShootProjectResourceName(shoot.Name, DashboardCredentialsSuffix),
+			Namespace: shoot.Namespace,
+		},
+	}
+	if err := a.gardenClient.Get(ctx, client.ObjectKeyFromObject(secret), secret); client.IgnoreNotFound(err) != nil {
+		return traefik.DashboardCredentials{}, fmt.Errorf("failed to get dashboard credentials: %w", err)
+	}
+
+	credentials := traefik.DashboardCredentials{
+		Username: string(secret.Data[dashboardCredentialsUsernameKey]),
+		Password: string(secret.Data[dashboardCredentialsPasswordKey]),
+		Users:    string(secret.Data[dashboardCredentialsUsersKey]),
+	}
+	if credentials.Valid() && string(secret.Data[dashboardCredentialsURLKey]) == "https://"+host {
+		return credentials, nil
+	}
+
+	if !credentials.Valid() {
+		generated, err := traefik.GenerateDashboardCredentials()
+		if err != nil {
+			return traefik.DashboardCredentials{}, err
+		}
+		credentials = generated
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+	secret.Labels = map[string]string{
+		"app.kubernetes.io/name":       "traefik",
+		"app.kubernetes.io/component":  "dashboard",
+		"app.kubernetes.io/managed-by": "gardener-extension-shoot-traefik",
+	}
+	secret.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(shoot, gardencorev1beta1.SchemeGroupVersion.WithKind("Shoot")),
+	}
+	secret.Type = corev1.SecretTypeOpaque
+	secret.Data = map[string][]byte{
+		dashboardCredentialsUsernameKey: []byte(credentials.Username),
+		dashboardCredentialsPasswordKey: []byte(credentials.Password),
+		dashboardCredentialsUsersKey:    []byte(credentials.Users),
+		dashboardCredentialsURLKey:      []byte("https://" + host),
+	}
+
+	if secret.ResourceVersion == "" {
+		if err := a.gardenClient.Create(ctx, secret); err != nil {
+			return traefik.DashboardCredentials{}, fmt.Errorf("failed to create dashboard credentials: %w", err)
+		}
+
+		return credentials, nil
+	}
+
+	if err := a.gardenClient.Patch(ctx, secret, patch); err != nil {
+		return traefik.DashboardCredentials{}, fmt.Errorf("failed to update dashboard credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// deleteDashboardCredentials deletes the BasicAuth credentials of the secure
+// dashboard from the project namespace of the shoot of the given cluster, if
+// the [Actuator] has access to the garden cluster.
+func (a *Actuator) deleteDashboardCredentials(ctx context.Context, clusterName string) error {
+	if a.gardenClient == nil {
+		return nil
+	}
+
+	cluster, err := extensionscontroller.GetCluster(ctx, a.client, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gardenerutils.ComputeShootProjectResourceName(cluster.Shoot.Name, DashboardCredentialsSuffix),
+			Namespace: cluster.Shoot.Namespace,
+		},
+	}
+	if err := a.gardenClient.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete dashboard credentials: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package actuator
+
+import (
+	"context"
+	"fmt"
+
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+// validateDynamicConfig validates the syntax of the dynamic configuration in
+// the ConfigMap of the file provider in the shoot cluster, before Traefik is
+// rolled out with it. Errors are reported as configuration problems of the
+// shoot owner in the status of the Extension.
+func (a *Actuator) validateDynamicConfig(ctx context.Context, shootClient client.Client, traefikConfig traefik.Config) error {
+	if traefikConfig.DynamicConfigMapName == "" {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: traefik.Namespace, Name: traefikConfig.DynamicConfigMapName}
+	if err := shootClient.Get(ctx, key, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return v1beta1helper.NewErrorWithCodes(
+				fmt.Errorf("traefik dynamic config ConfigMap %s not found in the shoot cluster", key),
+				gardencorev1beta1.ErrorConfigurationProblem,
+			)
+		}
+
+		return fmt.Errorf("failed to get traefik dynamic config ConfigMap %s: %w", key, err)
+	}
+
+	if err := traefik.ValidateDynamicConfig(configMap.Data); err != nil {
+		return v1beta1helper.NewErrorWithCodes(
+			fmt.Errorf("invalid ConfigMap %s: %w", key, err),
+			gardencorev1beta1.ErrorConfigurationProblem,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package actuator
+
+import (
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+// Reasons of the events, which are recorded for the reconciled extension
+// resources.
+const (
+	// EventReasonDeployed is the reason of the event, which is recorded when
+	// Traefik is deployed to or scaled down in the shoot cluster.
+	EventReasonDeployed = "TraefikDeployed"
+	// EventReasonDeployFailed is the reason of the event, which is recorded
+	// when Traefik cannot be deployed to the shoot cluster.
+	EventReasonDeployFailed = "TraefikDeployFailed"
+	// EventReasonRolledBack is the reason of the event, which is recorded when
+	// Traefik is rolled back to the last known good configuration.
+	EventReasonRolledBack = "TraefikRolledBack"
+	// EventReasonDNSRecordCreated is the reason of the event, which is
+	// recorded when the ingress DNS record of a shoot is created.
+	EventReasonDNSRecordCreated = "TraefikDNSRecordCreated"
+	// EventReasonDNSRecordUpdated is the reason of the event, which is
+	// recorded when the ingress DNS record of a shoot is updated.
+	EventReasonDNSRecordUpdated = "TraefikDNSRecordUpdated"
+	// EventReasonDNSRecordDeleted is the reason of the event, which is
+	// recorded when the ingress DNS record of a shoot is deleted.
+	EventReasonDNSRecordDeleted = "TraefikDNSRecordDeleted"
+	// EventReasonIngressAddressPending is the reason of the event, which is
+	// recorded while the ingress DNS record waits for an address of Traefik,
+	// e.g. of its LoadBalancer Service.
+	EventReasonIngressAddressPending = "TraefikIngressAddressPending"
+	// EventReasonConfigFallback is the reason of the event, which is recorded
+	// when a setting of the provider config cannot be applied and a default
+	// is used instead.
+	EventReasonConfigFallback = "TraefikConfigFallback"
+	// EventReasonNginxAnnotationsUnsupported is the reason of the event,
+	// which is recorded when Ingress resources of a shoot using the
+	// KubernetesIngressNGINX provider have nginx annotations, which Traefik
+	// does not fully support.
+	EventReasonNginxAnnotationsUnsupported = "TraefikNginxAnnotationsUnsupported"
+)
+
+const (
+	actionDeploy          = "Deploy"
+	actionRollback        = "Rollback"
+	actionDeployDNSRecord = "DeployDNSRecord"
+	actionDeleteDNSRecord = "DeleteDNSRecord"
+	actionDecodeConfig    = "DecodeProviderConfig"
+	actionCheckNginx      = "CheckNginxCompatibility"
+)
+
+// recordEvent records an event of the given type for the given extension, if
+// the [Actuator] is configured with an event recorder.
+func (a *Actuator) recordEvent(ex *extensionsv1alpha1.Extension, eventtype, reason, action, note string, args ...any) {
+	if a.recorder == nil {
+		return
+	}
+
+	a.recorder.Eventf(ex, nil, eventtype, reason, action, note, args...)
+}
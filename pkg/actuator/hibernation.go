@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package actuator
+
+import (
+	"context"
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/metrics"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+// hibernate scales Traefik in the hibernated shoot cluster to zero and deletes
+// or keeps the ingress DNS record according to the hibernation settings of
+// the shoot. Traefik and the ingress DNS record are restored by the regular
+// reconciliation, once the shoot is woken up and the LoadBalancer of Traefik
+// has an address again.
+//
+// The shoot cluster is not contacted, because its API server may already be
+// scaled down. Therefore, the backends of Traefik are not discovered and the
+// Deployment is scaled down via the ManagedResource, which is applied by
+// gardener-resource-manager as long as the API server is running.
+func (a *Actuator) hibernate(ctx context.Context, logger logr.Logger, cluster *extensionscontroller.Cluster, ex *extensionsv1alpha1.Extension) error {
+	clusterName := ex.Namespace
+
+	spec, _, err := a.deployedConfig(ctx, logger, ex)
+	if err != nil {
+		return err
+	}
+	// The configuration of the hibernated shoot scales Traefik down, unless
+	// it runs as DaemonSet, whose pods are removed together with the nodes.
+	traefikConfig, err := a.traefikConfig(ctx, logger, cluster, ex, spec)
+	if err != nil {
+		return err
+	}
+
+	deployer := traefik.NewDeployer(a.client, logger, traefikConfig, a.imageVector)
+	if err := deployer.Deploy(ctx, clusterName); err != nil {
+		metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonDeploy).Inc()
+		a.recordEvent(ex, corev1.EventTypeWarning, EventReasonDeployFailed, actionDeploy, "Failed to scale down Traefik: %v", err)
+
+		return fmt.Errorf("failed to scale down traefik: %w", err)
+	}
+	if !traefikConfig.DaemonSetMode() {
+		a.recordEvent(ex, corev1.EventTypeNormal, EventReasonDeployed, actionDeploy, "Scaled down Traefik, because the shoot is hibernated")
+	}
+	metrics.SetManagedShoot(clusterName, string(traefikConfig.IngressProvider), a.traefikVersion(traefikConfig))
+
+	if traefikConfig.HibernationDNSRecordPolicy == config.DNSRecordPolicyKeep {
+		logger.Info("keeping traefik ingress DNS record of hibernated shoot", "cluster", clusterName)
+
+		return nil
+	}
+
+	if err := a.deleteDNSRecord(ctx, logger, ex, deployer, "the shoot is hibernated"); err != nil {
+		metrics.ActuatorErrorsTotal.WithLabelValues(metrics.ErrorReasonDNS).Inc()
+
+		return err
+	}
+
+	return nil
+}
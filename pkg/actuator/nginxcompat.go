@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package actuator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+// DefaultNginxCompatibilityCheckInterval is the default minimum interval
+// between the nginx annotation compatibility checks of a shoot.
+const DefaultNginxCompatibilityCheckInterval = 10 * time.Minute
+
+// reconcileNginxCompatibility checks the support of the nginx annotations of
+// the Ingress resources in the shoot cluster by Traefik, if the shoot uses the
+// KubernetesIngressNGINX provider, and publishes the report as ConfigMap in the
+// shoot cluster. As the extension is reconciled periodically, the annotations
+// are checked at most once per check interval. The report is removed, if the
+// shoot uses another provider.
+func (a *Actuator) reconcileNginxCompatibility(ctx context.Context, logger logr.Logger, ex *extensionsv1alpha1.Extension, shootClient client.Client, deployer *traefik.Deployer, traefikConfig traefik.Config) error {
+	clusterName := ex.Namespace
+	if traefikConfig.IngressProvider != config.IngressProviderKubernetesIngressNGINX {
+		return a.deleteNginxCompatibility(ctx, clusterName, deployer, false)
+	}
+
+	if last, ok := a.nginxChecks.Load(clusterName); ok && time.Since(last.(time.Time)) < a.nginxCheckInterval {
+		return nil
+	}
+
+	report, err := traefik.AnalyzeNginxCompatibility(ctx, shootClient, traefikConfig)
+	if err != nil {
+		return fmt.Errorf("failed to analyze nginx annotations: %w", err)
+	}
+
+	if err := deployer.DeployNginxCompatibilityReport(ctx, clusterName, report); err != nil {
+		return err
+	}
+	a.nginxChecks.Store(clusterName, time.Now())
+
+	logger.Info("checked nginx annotation compatibility", "cluster", clusterName,
+		"ingresses", report.Ingresses, "partiallySupported", report.PartiallySupported, "unsupported", report.Unsupported)
+	if report.PartiallySupported > 0 || report.Unsupported > 0 {
+		a.recordEvent(ex, corev1.EventTypeWarning, EventReasonNginxAnnotationsUnsupported, actionCheckNginx,
+			"%d Ingress resources use %d unsupported and %d partially supported nginx annotations, see ConfigMap %s/%s in the shoot cluster",
+			len(report.Incompatible), report.Unsupported, report.PartiallySupported, traefik.Namespace, traefik.NginxCompatibilityConfigMapName)
+	}
+
+	return nil
+}
+
+// deleteNginxCompatibility removes the nginx annotation compatibility report
+// of the shoot. The ConfigMap is kept in the shoot cluster, if keepObjects is
+// true.
+func (a *Actuator) deleteNginxCompatibility(ctx context.Context, clusterName string, deployer *traefik.Deployer, keepObjects bool) error {
+	a.nginxChecks.Delete(clusterName)
+
+	if err := deployer.DeleteNginxCompatibilityReport(ctx, clusterName, keepObjects); err != nil {
+		return fmt.Errorf("failed to delete nginx compatibility report: %w", err)
+	}
+
+	return nil
+}
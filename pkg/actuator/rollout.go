@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package actuator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+// verifyRollout checks the rollout of Traefik in the shoot cluster, so that
+// a broken rollout, e.g. due to a crashing Traefik, fails the reconciliation
+// instead of being reported as successful. The rollout is not waited for:
+// while it is progressing, a [reconcilerutils.RequeueAfterError] is returned,
+// so that the reconciliation is retried without blocking a worker, and the
+// last operation of the extension reports the rollout as processing.
+//
+// While the shoot has no ready nodes, e.g. during its creation or wake-up,
+// Traefik cannot be rolled out, and a [reconcilerutils.RequeueAfterError] is
+// returned as well, so that the rollout is verified, after the worker nodes
+// joined the shoot.
+//
+// The provider config spec of a successfully rolled out configuration is
+// stored as the last known good configuration, and a reported rollback is
+// removed from the status of the extension, unless the rolled back
+// configuration is kept. If the rollout fails, Traefik is rolled back to the
+// last known good configuration, see [Actuator.rollback].
+func (a *Actuator) verifyRollout(ctx context.Context, logger logr.Logger, cluster *extensionscontroller.Cluster, ex *extensionsv1alpha1.Extension, shootClient client.Client, deployer *traefik.Deployer, spec *config.TraefikConfigSpec, keepRollback bool) error {
+	if a.rolloutTimeout == 0 {
+		return nil
+	}
+
+	clusterName := ex.Namespace
+	ready, err := traefik.HasReadyNodes(ctx, shootClient)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		logger.Info("shoot has no ready nodes yet, waiting for the traefik rollout", "cluster", clusterName)
+
+		return &reconcilerutils.RequeueAfterError{Cause: errShootNodesPending, RequeueAfter: rolloutRequeueInterval}
+	}
+
+	if err := deployer.CheckRollout(ctx, clusterName, shootClient); err != nil {
+		if errors.Is(err, traefik.ErrRolloutFailed) {
+			return a.rollback(ctx, logger, cluster, ex, shootClient, spec, err)
+		}
+		logger.Info("waiting for traefik to be rolled out", "cluster", clusterName, "reason", err.Error())
+
+		return &reconcilerutils.RequeueAfterError{Cause: err, RequeueAfter: rolloutRequeueInterval}
+	}
+
+	if err := traefik.SaveLastKnownGoodConfig(ctx, a.client, clusterName, spec); err != nil {
+		return err
+	}
+	if keepRollback {
+		return nil
+	}
+
+	return a.updateRollbackStatus(ctx, ex, nil)
+}
+
+// rolledBackConfig returns the provider config spec of the last known good
+// configuration of Traefik and the rollback reported in the status of the
+// given extension, if the given provider config spec of the extension is the
+// configuration, whose rollout failed. The last known good configuration is
+// kept deployed in this case, so that the failed configuration is only
+// retried, after the provider config changed.
+func (a *Actuator) rolledBackConfig(ctx context.Context, ex *extensionsv1alpha1.Extension, providerConfigSpec *config.TraefikConfigSpec) (*config.TraefikConfigSpec, *config.RollbackStatus, error) {
+	if ex.Status.ProviderStatus == nil || len(ex.Status.ProviderStatus.Raw) == 0 {
+		return nil, nil, nil
+	}
+
+	status := &config.TraefikStatus{}
+	if err := runtime.DecodeInto(a.decoder, ex.Status.ProviderStatus.Raw, status); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode traefik status: %w", err)
+	}
+	if status.Rollback == nil || !apiequality.Semantic.DeepEqual(providerConfigSpec, status.Rollback.FailedConfig) {
+		return nil, nil, nil
+	}
+
+	lastKnownGood, found, err := traefik.LastKnownGoodConfig(ctx, a.client, ex.Namespace)
+	if err != nil || !found {
+		return nil, nil, err
+	}
+
+	return lastKnownGood, status.Rollback, nil
+}
+
+// rollback re-applies the last known good configuration of Traefik, after the
+// rollout of the given provider config spec failed with the given error, and
+// reports the failed spec in the status of the extension. The configuration
+// of Traefik is rebuilt from the spec of the last known good configuration,
+// so that the settings derived from the shoot are up to date, see
+// [Actuator.shootConfig]. The rollout error is returned in any case, so that
+// the reconciliation fails. The failed configuration is not retried, until
+// the provider config changes, see [Actuator.rolledBackConfig].
+func (a *Actuator) rollback(ctx context.Context, logger logr.Logger, cluster *extensionscontroller.Cluster, ex *extensionsv1alpha1.Extension, shootClient client.Client, spec *config.TraefikConfigSpec, rolloutErr error) error {
+	clusterName := ex.Namespace
+	lastKnownGood, found, err := traefik.LastKnownGoodConfig(ctx, a.client, clusterName)
+	if err != nil {
+		return errors.Join(rolloutErr, err)
+	}
+	if !found || apiequality.Semantic.DeepEqual(lastKnownGood, spec) {
+		return rolloutErr
+	}
+
+	logger.Info("rolling back traefik to the last known good configuration", "cluster", clusterName, "reason", rolloutErr.Error())
+	traefikConfig, err := a.shootConfig(ctx, logger, cluster, ex, shootClient, lastKnownGood)
+	if err != nil {
+		return errors.Join(rolloutErr, fmt.Errorf("failed to roll back traefik: %w", err))
+	}
+	deployer := traefik.NewDeployer(a.client, logger, traefikConfig, a.imageVector)
+	if err := deployer.Deploy(ctx, clusterName); err != nil {
+		return errors.Join(rolloutErr, fmt.Errorf("failed to roll back traefik: %w", err))
+	}
+
+	if err := a.updateRollbackStatus(ctx, ex, &config.RollbackStatus{
+		FailedConfig: spec,
+		Reason:       rolloutErr.Error(),
+		Time:         metav1.Now(),
+	}); err != nil {
+		return errors.Join(rolloutErr, err)
+	}
+
+	a.recordEvent(ex, corev1.EventTypeWarning, EventReasonRolledBack, actionRollback, "Rolled back Traefik to the last known good configuration: %v", rolloutErr)
+
+	return fmt.Errorf("rolled back traefik to the last known good configuration: %w", rolloutErr)
+}
+
+// updateRollbackStatus reports the given rollback in the provider status of
+// the extension. The provider status is removed, if the rollback is nil.
+func (a *Actuator) updateRollbackStatus(ctx context.Context, ex *extensionsv1alpha1.Extension, rollback *config.RollbackStatus) error {
+	patch := client.MergeFrom(ex.DeepCopy())
+	if rollback == nil {
+		if ex.Status.ProviderStatus == nil {
+			return nil
+		}
+		ex.Status.ProviderStatus = nil
+	} else {
+		status := &v1alpha1.TraefikStatus{}
+		if err := a.client.Scheme().Convert(&config.TraefikStatus{Rollback: rollback}, status, nil); err != nil {
+			return fmt.Errorf("failed to convert traefik status: %w", err)
+		}
+		status.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind("TraefikStatus"))
+		ex.Status.ProviderStatus = &runtime.RawExtension{Object: status}
+	}
+
+	if err := a.client.Status().Patch(ctx, ex, patch); err != nil {
+		return fmt.Errorf("failed to update extension status: %w", err)
+	}
+
+	return nil
+}
@@ -7,7 +7,11 @@ package validator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
@@ -15,6 +19,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config/validation"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/metrics"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/operatorconfig"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
 )
 
 const (
@@ -26,14 +36,18 @@ const (
 
 // shootValidator validates Shoot resources for the Traefik extension.
 type shootValidator struct {
-	client  client.Client
-	decoder runtime.Decoder
+	client            client.Client
+	decoder           runtime.Decoder
+	supportedVersions []string
+	operatorConfig    *operatorconfig.Store
 }
 
 // NewShootValidatorWebhook creates a new webhook for validating Shoot resources.
 // It ensures that the Traefik extension can only be enabled for shoots with
-// purpose "evaluation".
-func NewShootValidatorWebhook(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
+// purpose "evaluation", that only the given Traefik versions are requested and
+// that additional arguments match the allowed argument prefixes of the given
+// operator configuration.
+func NewShootValidatorWebhook(mgr manager.Manager, supportedVersions []string, operatorConfig *operatorconfig.Store) (*extensionswebhook.Webhook, error) {
 	decoder := serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder()
 
 	return extensionswebhook.New(mgr, extensionswebhook.Args{
@@ -42,21 +56,43 @@ func NewShootValidatorWebhook(mgr manager.Manager) (*extensionswebhook.Webhook,
 		Path:     "/webhooks/validate-shoot-traefik",
 		Target:   extensionswebhook.TargetSeed,
 		Validators: map[extensionswebhook.Validator][]extensionswebhook.Type{
-			NewShootValidator(mgr.GetClient(), decoder): {
+			NewShootValidator(mgr.GetClient(), decoder, supportedVersions, operatorConfig): {
 				{Obj: &gardencorev1beta1.Shoot{}},
 			},
 		},
 	})
 }
 
-// NewShootValidator creates a new shoot validator.
-func NewShootValidator(c client.Client, decoder runtime.Decoder) extensionswebhook.Validator {
+// NewShootValidator creates a new shoot validator, which accepts the given
+// Traefik versions and additional arguments matching the allowed argument
+// prefixes of the given operator configuration in the provider config of the
+// extension. Additional arguments are denied without operator configuration.
+func NewShootValidator(c client.Client, decoder runtime.Decoder, supportedVersions []string, operatorConfig *operatorconfig.Store) extensionswebhook.Validator {
 	return &shootValidator{
-		client:  c,
-		decoder: decoder,
+		client:            c,
+		decoder:           decoder,
+		supportedVersions: supportedVersions,
+		operatorConfig:    operatorConfig,
 	}
 }
 
+// admissionError is an error, which denies a Shoot for the given reason. The
+// reason is one of the admission reasons of the [metrics] package.
+type admissionError struct {
+	reason string
+	err    error
+}
+
+// Error implements the [error] interface.
+func (e *admissionError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *admissionError) Unwrap() error {
+	return e.err
+}
+
 // Validate validates the given object (Shoot) on create and update operations.
 func (v *shootValidator) Validate(ctx context.Context, newClient, old client.Object) error {
 	shoot, ok := newClient.(*gardencorev1beta1.Shoot)
@@ -64,27 +100,48 @@ func (v *shootValidator) Validate(ctx context.Context, newClient, old client.Obj
 		return fmt.Errorf("expected *gardencorev1beta1.Shoot but got %T", newClient)
 	}
 
-	return v.validateShoot(shoot)
+	start := time.Now()
+	err := v.validateShoot(shoot)
+	observeAdmission(start, err)
+
+	return err
+}
+
+// observeAdmission records the decision for a Shoot, whose validation started
+// at the given time and returned the given error, in the webhook metrics.
+func observeAdmission(start time.Time, err error) {
+	if err == nil {
+		metrics.ObserveShootAdmission(metrics.DecisionAllowed, metrics.AdmissionReasonNone, start)
+
+		return
+	}
+
+	reason := metrics.AdmissionReasonInvalidConfig
+	var admissionErr *admissionError
+	if errors.As(err, &admissionErr) {
+		reason = admissionErr.reason
+	}
+	metrics.ObserveShootAdmission(metrics.DecisionDenied, reason, start)
 }
 
 // validateShoot validates that if the Traefik extension is enabled,
-// the shoot must have purpose "evaluation".
+// the shoot must have purpose "evaluation" and a valid provider config.
 func (v *shootValidator) validateShoot(shoot *gardencorev1beta1.Shoot) error {
 	// Check if the Traefik extension is configured and enabled
-	hasTraefikExtension := false
-	for _, ext := range shoot.Spec.Extensions {
+	var traefikExtension *gardencorev1beta1.Extension
+	for i, ext := range shoot.Spec.Extensions {
 		if ext.Type == ExtensionType {
 			if ext.Disabled != nil && *ext.Disabled {
 				return nil
 			}
-			hasTraefikExtension = true
+			traefikExtension = &shoot.Spec.Extensions[i]
 
 			break
 		}
 	}
 
 	// If no Traefik extension, validation passes
-	if !hasTraefikExtension {
+	if traefikExtension == nil {
 		return nil
 	}
 
@@ -95,13 +152,73 @@ func (v *shootValidator) validateShoot(shoot *gardencorev1beta1.Shoot) error {
 			purposeStr = string(*shoot.Spec.Purpose)
 		}
 
-		return fmt.Errorf(
+		return &admissionError{reason: metrics.AdmissionReasonPurpose, err: fmt.Errorf(
 			"traefik extension can only be enabled for shoots with purpose 'evaluation'. "+
 				"Current purpose: %s. Traefik acts as a replacement for the nginx ingress controller "+
 				"and is only supported for evaluation clusters",
 			purposeStr,
-		)
+		)}
+	}
+
+	return v.validateProviderConfig(traefikExtension)
+}
+
+// validateProviderConfig validates the provider config of the Traefik
+// extension, if specified.
+func (v *shootValidator) validateProviderConfig(ext *gardencorev1beta1.Extension) error {
+	if ext.ProviderConfig == nil {
+		return nil
+	}
+
+	var cfg config.TraefikConfig
+	if err := runtime.DecodeInto(v.decoder, ext.ProviderConfig.Raw, &cfg); err != nil {
+		return &admissionError{reason: metrics.AdmissionReasonInvalidConfig, err: fmt.Errorf("invalid traefik provider config: %w", err)}
+	}
+
+	if err := validation.ValidateTraefikConfig(&cfg).ToAggregate(); err != nil {
+		return &admissionError{reason: metrics.AdmissionReasonInvalidConfig, err: fmt.Errorf("invalid traefik provider config: %w", err)}
+	}
+
+	if cfg.Spec.Version != "" {
+		version := "v" + strings.TrimPrefix(cfg.Spec.Version, "v")
+		if !slices.Contains(v.supportedVersions, version) {
+			return &admissionError{reason: metrics.AdmissionReasonInvalidConfig, err: fmt.Errorf(
+				"traefik version %q is not supported. Supported versions: %s",
+				cfg.Spec.Version,
+				strings.Join(v.supportedVersions, ", "),
+			)}
+		}
+	}
+
+	// The extension only deploys additional arguments allowed by the
+	// operator, so other arguments would fail every reconciliation.
+	allowedArgumentPrefixes := v.allowedArgumentPrefixes()
+	for _, arg := range cfg.Spec.AdditionalArguments {
+		if len(allowedArgumentPrefixes) == 0 {
+			return &admissionError{reason: metrics.AdmissionReasonInvalidConfig, err: errors.New(
+				"traefik additional arguments are not allowed by the operator",
+			)}
+		}
+		if !traefik.ArgumentAllowed(arg, allowedArgumentPrefixes) {
+			return &admissionError{reason: metrics.AdmissionReasonInvalidConfig, err: fmt.Errorf(
+				"traefik additional argument %q is not allowed. Allowed argument prefixes: %s",
+				arg,
+				strings.Join(allowedArgumentPrefixes, ", "),
+			)}
+		}
 	}
 
 	return nil
 }
+
+// allowedArgumentPrefixes returns the argument prefixes, which shoot owners
+// may use in their additional arguments, from the current operator
+// configuration.
+func (v *shootValidator) allowedArgumentPrefixes() []string {
+	cfg := v.operatorConfig.Get()
+	if cfg == nil {
+		return nil
+	}
+
+	return cfg.Limits.AllowedArgumentPrefixes
+}
@@ -11,10 +11,17 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	configinstall "github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config/install"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/metrics"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/operatorconfig"
 )
 
 func TestValidator(t *testing.T) {
@@ -31,12 +38,19 @@ var _ = Describe("Shoot Validator", func() {
 	BeforeEach(func() {
 		scheme = runtime.NewScheme()
 		Expect(gardencorev1beta1.AddToScheme(scheme)).To(Succeed())
+		configinstall.Install(scheme)
 
 		client := fake.NewClientBuilder().WithScheme(scheme).Build()
 		decoder := serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder()
 		validator = &shootValidator{
-			client:  client,
-			decoder: decoder,
+			client:            client,
+			decoder:           decoder,
+			supportedVersions: []string{"v3.6.13", "v3.6.11"},
+			operatorConfig: operatorconfig.NewStore(&config.TraefikOperatorConfiguration{
+				Limits: config.OperatorLimits{
+					AllowedArgumentPrefixes: []string{"--accesslog", "--pingFoo"},
+				},
+			}),
 		}
 	})
 
@@ -180,4 +194,324 @@ var _ = Describe("Shoot Validator", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Context("when shoot has a traefik provider config", func() {
+		newShoot := func(providerConfig string) *gardencorev1beta1.Shoot {
+			purpose := gardencorev1beta1.ShootPurposeEvaluation
+
+			return &gardencorev1beta1.Shoot{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "core.gardener.cloud/v1beta1",
+					Kind:       "Shoot",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-shoot",
+					Namespace: "garden-test",
+				},
+				Spec: gardencorev1beta1.ShootSpec{
+					Purpose: &purpose,
+					Extensions: []gardencorev1beta1.Extension{
+						{
+							Type:           ExtensionType,
+							ProviderConfig: &runtime.RawExtension{Raw: []byte(providerConfig)},
+						},
+					},
+				},
+			}
+		}
+
+		It("should allow a supported traefik version", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"version":"v3.6.11"}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow a supported traefik version without prefix", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"version":"3.6.13"}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny an unknown traefik version", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"version":"v2.11.0"}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("v3.6.13, v3.6.11"))
+		})
+
+		It("should deny an invalid provider config", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"unknown":true}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid traefik provider config"))
+		})
+
+		It("should allow namespaces and a label selector", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"namespaces":["app-a","app-b"],"labelSelector":"team=a"}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny an invalid namespace", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"namespaces":["App_A"]}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.namespaces[0]"))
+		})
+
+		It("should deny an invalid label selector", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"labelSelector":"team in"}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.labelSelector"))
+		})
+
+		It("should deny multiple namespaces for the NGINX provider", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"ingressProvider":"KubernetesIngressNGINX","namespaces":["app-a","app-b"]}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.namespaces"))
+		})
+
+		It("should allow a secure dashboard with OIDC", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"dashboard":true,"secureDashboard":{"auth":"OIDC","forwardAuth":{"address":"https://oauth2-proxy.example.com/oauth2/auth"}}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny a secure dashboard with OIDC without forward-auth", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"dashboard":true,"secureDashboard":{"auth":"OIDC"}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.secureDashboard.forwardAuth"))
+		})
+
+		It("should allow a restricted network policy", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"networkPolicy":{"mode":"Restricted","backendNamespaceSelector":{"matchLabels":{"ingress":"traefik"}}}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny an invalid backend namespace selector", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"networkPolicy":{"mode":"Restricted","backendNamespaceSelector":{"matchExpressions":[{"key":"ingress","operator":"Exists","values":["traefik"]}]}}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.networkPolicy.backendNamespaceSelector"))
+		})
+
+		It("should allow default middlewares", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"defaultMiddlewares":{"securityHeaders":{"stsPreload":true},"rateLimit":{"average":100,"period":"1m"},"ipAllowList":{"sourceRange":["10.0.0.0/8","192.168.1.1"]},"maxRequestBodyBytes":1048576}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny an invalid IP allowlist", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"defaultMiddlewares":{"ipAllowList":{"sourceRange":["10.0.0.0/33"]}}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.defaultMiddlewares.ipAllowList.sourceRange[0]"))
+		})
+
+		It("should deny a rate limit without an average", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"defaultMiddlewares":{"rateLimit":{"burst":10}}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.defaultMiddlewares.rateLimit.average"))
+		})
+
+		It("should allow error pages from a ConfigMap", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"errorPages":{"configMapName":"my-error-pages"}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny error pages from a ConfigMap managed by the extension", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"errorPages":{"configMapName":"traefik-error-pages"}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.errorPages.configMapName"))
+		})
+
+		It("should allow additional arguments", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"additionalArguments":["--accesslog=true","--pingFoo=1"]}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny additional arguments conflicting with managed arguments", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"additionalArguments":["--accesslog=true","--Providers.KubernetesCRD=true","--metrics.prometheus=false"]}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.additionalArguments[1]"))
+			Expect(err.Error()).To(ContainSubstring("spec.additionalArguments[2]"))
+			Expect(err.Error()).NotTo(ContainSubstring("spec.additionalArguments[0]"))
+		})
+
+		It("should deny additional arguments not allowed by the operator", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"additionalArguments":["--accesslog=true","--accesslogs=true"]}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`traefik additional argument "--accesslogs=true" is not allowed`))
+		})
+
+		It("should deny additional arguments without allowed argument prefixes", func() {
+			validator.operatorConfig = nil
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"additionalArguments":["--accesslog=true"]}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("additional arguments are not allowed by the operator"))
+		})
+
+		It("should deny malformed additional arguments", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"additionalArguments":["accesslog"]}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be of the form"))
+		})
+
+		It("should allow a dynamic config", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"dynamicConfig":{"configMapName":"my-dynamic-config"}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow scheduling onto a dedicated worker pool", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"scheduling":{"nodeSelector":{"worker.gardener.cloud/pool":"ingress"},"tolerations":[{"key":"dedicated","operator":"Equal","value":"ingress","effect":"NoSchedule"}],"priorityClassName":"system-node-critical"}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow the DaemonSet mode with host ports", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"deploymentMode":"DaemonSet","hostPort":true}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny the host network in the Deployment mode", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"hostNetwork":true}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.hostNetwork"))
+		})
+
+		It("should allow a rollout with a longer shutdown delay", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"rollout":{"maxSurge":"50%","shutdownDelay":"30s"}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny rolling update parameters in the DaemonSet mode", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"deploymentMode":"DaemonSet","rollout":{"maxUnavailable":2}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.rollout.maxUnavailable"))
+		})
+
+		It("should allow keeping the ingress DNS record during hibernation", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"hibernation":{"dnsRecordPolicy":"Keep"}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny an unknown DNS record policy for hibernation", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"hibernation":{"dnsRecordPolicy":"Retain"}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.hibernation.dnsRecordPolicy"))
+		})
+
+		It("should deny an invalid toleration", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"scheduling":{"tolerations":[{"key":"dedicated","operator":"Equal","value":"ingress","effect":"NoRun"}]}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.scheduling.tolerations[0].effect"))
+		})
+
+		It("should deny a dynamic config from a ConfigMap managed by the extension", func() {
+			shoot := newShoot(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"dynamicConfig":{"configMapName":"traefik-error-pages-nginx"}}}`)
+
+			err := validator.Validate(context.Background(), shoot, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.dynamicConfig.configMapName"))
+		})
+	})
+
+	Context("metrics", func() {
+		admissions := func(decision, reason string) float64 {
+			return testutil.ToFloat64(metrics.WebhookShootAdmissionTotal.WithLabelValues(decision, reason))
+		}
+
+		DescribeTable("should count the decision with its reason",
+			func(shoot *gardencorev1beta1.Shoot, decision, reason string) {
+				before := admissions(decision, reason)
+				_ = validator.Validate(context.Background(), shoot, nil)
+				Expect(admissions(decision, reason)).To(Equal(before + 1))
+			},
+			Entry("allowed", newEvaluationShoot(nil), metrics.DecisionAllowed, metrics.AdmissionReasonNone),
+			Entry("purpose", func() *gardencorev1beta1.Shoot {
+				shoot := newEvaluationShoot(nil)
+				shoot.Spec.Purpose = ptr.To(gardencorev1beta1.ShootPurposeProduction)
+
+				return shoot
+			}(), metrics.DecisionDenied, metrics.AdmissionReasonPurpose),
+			Entry("invalid config", newEvaluationShoot(ptr.To(`{"apiVersion":"traefik.extensions.gardener.cloud/v1alpha1","kind":"TraefikConfig","spec":{"version":"v2.0.0"}}`)),
+				metrics.DecisionDenied, metrics.AdmissionReasonInvalidConfig),
+		)
+
+		It("should observe the duration of the validation", func() {
+			Expect(validator.Validate(context.Background(), newEvaluationShoot(nil), nil)).To(Succeed())
+			Expect(testutil.CollectAndCount(metrics.WebhookShootAdmissionDurationSeconds, "gardener_extension_traefik_webhook_shoot_admission_duration_seconds")).To(BeNumerically(">=", 1))
+		})
+	})
 })
+
+// newEvaluationShoot returns a Shoot with the purpose "evaluation" and the
+// Traefik extension with the given provider config.
+func newEvaluationShoot(providerConfig *string) *gardencorev1beta1.Shoot {
+	shoot := &gardencorev1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-shoot",
+			Namespace: "garden-test",
+		},
+		Spec: gardencorev1beta1.ShootSpec{
+			Purpose:    ptr.To(gardencorev1beta1.ShootPurposeEvaluation),
+			Extensions: []gardencorev1beta1.Extension{{Type: ExtensionType}},
+		},
+	}
+	if providerConfig != nil {
+		shoot.Spec.Extensions[0].ProviderConfig = &runtime.RawExtension{Raw: []byte(*providerConfig)}
+	}
+
+	return shoot
+}
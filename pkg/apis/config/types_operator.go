@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TraefikOperatorConfiguration is the configuration of the Traefik extension,
+// which is provided by the operator of the extension via the `--config' flag
+// of the manager. It holds the global defaults for all shoots, the limits shoot
+// owners cannot exceed and the feature toggles of the extension.
+type TraefikOperatorConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Defaults are applied to all shoots, which do not specify the respective
+	// setting in their [TraefikConfig].
+	Defaults OperatorDefaults `json:"defaults"`
+
+	// Limits restrict the settings shoot owners can choose in their
+	// [TraefikConfig].
+	Limits OperatorLimits `json:"limits"`
+
+	// Features toggles optional features of the extension.
+	Features OperatorFeatures `json:"features"`
+}
+
+// OperatorDefaults defines the global defaults of the Traefik extension.
+type OperatorDefaults struct {
+	// Replicas is the default number of Traefik replicas.
+	// Defaults to 2 if not specified.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources are the resource requirements of the Traefik container.
+	// Defaults to requests of 100m CPU and 128Mi memory and limits of 500m CPU
+	// and 512Mi memory if not specified.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// LogLevel is the default Traefik log level.
+	// Valid values are: Debug, Info, Warn, Error, Fatal, Panic
+	// Defaults to "Info" if not specified.
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// IngressProvider is the default Kubernetes Ingress provider.
+	// Defaults to "KubernetesIngress" if not specified.
+	IngressProvider IngressProviderType `json:"ingressProvider,omitempty"`
+
+	// Version selects the default Traefik image from the image vector, e.g.
+	// "v3.6.11". Defaults to the first Traefik image of the image vector if
+	// not specified.
+	Version string `json:"version,omitempty"`
+}
+
+// OperatorLimits defines the limits shoot owners cannot exceed.
+type OperatorLimits struct {
+	// MaxReplicas is the maximum number of Traefik replicas a shoot owner can
+	// request. Unlimited if not specified.
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// AllowedIngressProviders is the list of Kubernetes Ingress providers a
+	// shoot owner can choose from. All providers are allowed if empty.
+	AllowedIngressProviders []IngressProviderType `json:"allowedIngressProviders,omitempty"`
+
+	// AllowedArgumentPrefixes is the list of Traefik argument prefixes shoot
+	// owners can use in their additional arguments, e.g. "--accesslog" or
+	// "--tracing.". Additional arguments are not allowed if empty.
+	AllowedArgumentPrefixes []string `json:"allowedArgumentPrefixes,omitempty"`
+}
+
+// OperatorFeatures defines the feature toggles of the Traefik extension.
+type OperatorFeatures struct {
+	// Dashboard allows shoot owners to enable the Traefik dashboard.
+	// Defaults to true if not specified.
+	Dashboard *bool `json:"dashboard,omitempty"`
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TraefikStatus is the status of the Traefik extension, which is reported in
+// the providerStatus of the Extension resource of a shoot.
+type TraefikStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Rollback is set, if the rollout of the configuration of Traefik failed
+	// and Traefik was rolled back to the last known good configuration.
+	Rollback *RollbackStatus `json:"rollback,omitempty"`
+}
+
+// RollbackStatus describes a rollback of Traefik to the last known good
+// configuration.
+type RollbackStatus struct {
+	// FailedConfig is the configuration of Traefik, whose rollout failed. It
+	// is not set, if the shoot does not specify a configuration.
+	FailedConfig *TraefikConfigSpec `json:"failedConfig,omitempty"`
+
+	// Reason is the reason, why the rollout failed.
+	Reason string `json:"reason"`
+
+	// Time is the time of the rollback.
+	Time metav1.Time `json:"time"`
+}
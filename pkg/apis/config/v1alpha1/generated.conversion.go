@@ -6,9 +6,14 @@
 package v1alpha1
 
 import (
+	unsafe "unsafe"
+
 	config "github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func init() {
@@ -18,6 +23,166 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*DefaultMiddlewares)(nil), (*config.DefaultMiddlewares)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DefaultMiddlewares_To_config_DefaultMiddlewares(a.(*DefaultMiddlewares), b.(*config.DefaultMiddlewares), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.DefaultMiddlewares)(nil), (*DefaultMiddlewares)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_DefaultMiddlewares_To_v1alpha1_DefaultMiddlewares(a.(*config.DefaultMiddlewares), b.(*DefaultMiddlewares), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DynamicConfig)(nil), (*config.DynamicConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DynamicConfig_To_config_DynamicConfig(a.(*DynamicConfig), b.(*config.DynamicConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.DynamicConfig)(nil), (*DynamicConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_DynamicConfig_To_v1alpha1_DynamicConfig(a.(*config.DynamicConfig), b.(*DynamicConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ErrorPages)(nil), (*config.ErrorPages)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ErrorPages_To_config_ErrorPages(a.(*ErrorPages), b.(*config.ErrorPages), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ErrorPages)(nil), (*ErrorPages)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ErrorPages_To_v1alpha1_ErrorPages(a.(*config.ErrorPages), b.(*ErrorPages), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ForwardAuth)(nil), (*config.ForwardAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ForwardAuth_To_config_ForwardAuth(a.(*ForwardAuth), b.(*config.ForwardAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ForwardAuth)(nil), (*ForwardAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ForwardAuth_To_v1alpha1_ForwardAuth(a.(*config.ForwardAuth), b.(*ForwardAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Hibernation)(nil), (*config.Hibernation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Hibernation_To_config_Hibernation(a.(*Hibernation), b.(*config.Hibernation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.Hibernation)(nil), (*Hibernation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_Hibernation_To_v1alpha1_Hibernation(a.(*config.Hibernation), b.(*Hibernation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*IPAllowList)(nil), (*config.IPAllowList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_IPAllowList_To_config_IPAllowList(a.(*IPAllowList), b.(*config.IPAllowList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.IPAllowList)(nil), (*IPAllowList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_IPAllowList_To_v1alpha1_IPAllowList(a.(*config.IPAllowList), b.(*IPAllowList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkPolicy)(nil), (*config.NetworkPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy(a.(*NetworkPolicy), b.(*config.NetworkPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.NetworkPolicy)(nil), (*NetworkPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy(a.(*config.NetworkPolicy), b.(*NetworkPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OperatorDefaults)(nil), (*config.OperatorDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OperatorDefaults_To_config_OperatorDefaults(a.(*OperatorDefaults), b.(*config.OperatorDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.OperatorDefaults)(nil), (*OperatorDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_OperatorDefaults_To_v1alpha1_OperatorDefaults(a.(*config.OperatorDefaults), b.(*OperatorDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OperatorFeatures)(nil), (*config.OperatorFeatures)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OperatorFeatures_To_config_OperatorFeatures(a.(*OperatorFeatures), b.(*config.OperatorFeatures), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.OperatorFeatures)(nil), (*OperatorFeatures)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_OperatorFeatures_To_v1alpha1_OperatorFeatures(a.(*config.OperatorFeatures), b.(*OperatorFeatures), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OperatorLimits)(nil), (*config.OperatorLimits)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OperatorLimits_To_config_OperatorLimits(a.(*OperatorLimits), b.(*config.OperatorLimits), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.OperatorLimits)(nil), (*OperatorLimits)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_OperatorLimits_To_v1alpha1_OperatorLimits(a.(*config.OperatorLimits), b.(*OperatorLimits), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RateLimit)(nil), (*config.RateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RateLimit_To_config_RateLimit(a.(*RateLimit), b.(*config.RateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RateLimit)(nil), (*RateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RateLimit_To_v1alpha1_RateLimit(a.(*config.RateLimit), b.(*RateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RollbackStatus)(nil), (*config.RollbackStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RollbackStatus_To_config_RollbackStatus(a.(*RollbackStatus), b.(*config.RollbackStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RollbackStatus)(nil), (*RollbackStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RollbackStatus_To_v1alpha1_RollbackStatus(a.(*config.RollbackStatus), b.(*RollbackStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Rollout)(nil), (*config.Rollout)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Rollout_To_config_Rollout(a.(*Rollout), b.(*config.Rollout), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.Rollout)(nil), (*Rollout)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_Rollout_To_v1alpha1_Rollout(a.(*config.Rollout), b.(*Rollout), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Scheduling)(nil), (*config.Scheduling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Scheduling_To_config_Scheduling(a.(*Scheduling), b.(*config.Scheduling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.Scheduling)(nil), (*Scheduling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_Scheduling_To_v1alpha1_Scheduling(a.(*config.Scheduling), b.(*Scheduling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SecureDashboard)(nil), (*config.SecureDashboard)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SecureDashboard_To_config_SecureDashboard(a.(*SecureDashboard), b.(*config.SecureDashboard), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.SecureDashboard)(nil), (*SecureDashboard)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_SecureDashboard_To_v1alpha1_SecureDashboard(a.(*config.SecureDashboard), b.(*SecureDashboard), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SecurityHeaders)(nil), (*config.SecurityHeaders)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SecurityHeaders_To_config_SecurityHeaders(a.(*SecurityHeaders), b.(*config.SecurityHeaders), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.SecurityHeaders)(nil), (*SecurityHeaders)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_SecurityHeaders_To_v1alpha1_SecurityHeaders(a.(*config.SecurityHeaders), b.(*SecurityHeaders), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*TraefikConfig)(nil), (*config.TraefikConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_TraefikConfig_To_config_TraefikConfig(a.(*TraefikConfig), b.(*config.TraefikConfig), scope)
 	}); err != nil {
@@ -38,9 +203,415 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TraefikOperatorConfiguration)(nil), (*config.TraefikOperatorConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TraefikOperatorConfiguration_To_config_TraefikOperatorConfiguration(a.(*TraefikOperatorConfiguration), b.(*config.TraefikOperatorConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.TraefikOperatorConfiguration)(nil), (*TraefikOperatorConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_TraefikOperatorConfiguration_To_v1alpha1_TraefikOperatorConfiguration(a.(*config.TraefikOperatorConfiguration), b.(*TraefikOperatorConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TraefikStatus)(nil), (*config.TraefikStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TraefikStatus_To_config_TraefikStatus(a.(*TraefikStatus), b.(*config.TraefikStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.TraefikStatus)(nil), (*TraefikStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_TraefikStatus_To_v1alpha1_TraefikStatus(a.(*config.TraefikStatus), b.(*TraefikStatus), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_DefaultMiddlewares_To_config_DefaultMiddlewares(in *DefaultMiddlewares, out *config.DefaultMiddlewares, s conversion.Scope) error {
+	out.SecurityHeaders = (*config.SecurityHeaders)(unsafe.Pointer(in.SecurityHeaders))
+	out.RateLimit = (*config.RateLimit)(unsafe.Pointer(in.RateLimit))
+	out.IPAllowList = (*config.IPAllowList)(unsafe.Pointer(in.IPAllowList))
+	out.MaxRequestBodyBytes = (*int64)(unsafe.Pointer(in.MaxRequestBodyBytes))
+	return nil
+}
+
+// Convert_v1alpha1_DefaultMiddlewares_To_config_DefaultMiddlewares is an autogenerated conversion function.
+func Convert_v1alpha1_DefaultMiddlewares_To_config_DefaultMiddlewares(in *DefaultMiddlewares, out *config.DefaultMiddlewares, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DefaultMiddlewares_To_config_DefaultMiddlewares(in, out, s)
+}
+
+func autoConvert_config_DefaultMiddlewares_To_v1alpha1_DefaultMiddlewares(in *config.DefaultMiddlewares, out *DefaultMiddlewares, s conversion.Scope) error {
+	out.SecurityHeaders = (*SecurityHeaders)(unsafe.Pointer(in.SecurityHeaders))
+	out.RateLimit = (*RateLimit)(unsafe.Pointer(in.RateLimit))
+	out.IPAllowList = (*IPAllowList)(unsafe.Pointer(in.IPAllowList))
+	out.MaxRequestBodyBytes = (*int64)(unsafe.Pointer(in.MaxRequestBodyBytes))
 	return nil
 }
 
+// Convert_config_DefaultMiddlewares_To_v1alpha1_DefaultMiddlewares is an autogenerated conversion function.
+func Convert_config_DefaultMiddlewares_To_v1alpha1_DefaultMiddlewares(in *config.DefaultMiddlewares, out *DefaultMiddlewares, s conversion.Scope) error {
+	return autoConvert_config_DefaultMiddlewares_To_v1alpha1_DefaultMiddlewares(in, out, s)
+}
+
+func autoConvert_v1alpha1_DynamicConfig_To_config_DynamicConfig(in *DynamicConfig, out *config.DynamicConfig, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_v1alpha1_DynamicConfig_To_config_DynamicConfig is an autogenerated conversion function.
+func Convert_v1alpha1_DynamicConfig_To_config_DynamicConfig(in *DynamicConfig, out *config.DynamicConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DynamicConfig_To_config_DynamicConfig(in, out, s)
+}
+
+func autoConvert_config_DynamicConfig_To_v1alpha1_DynamicConfig(in *config.DynamicConfig, out *DynamicConfig, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_config_DynamicConfig_To_v1alpha1_DynamicConfig is an autogenerated conversion function.
+func Convert_config_DynamicConfig_To_v1alpha1_DynamicConfig(in *config.DynamicConfig, out *DynamicConfig, s conversion.Scope) error {
+	return autoConvert_config_DynamicConfig_To_v1alpha1_DynamicConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_ErrorPages_To_config_ErrorPages(in *ErrorPages, out *config.ErrorPages, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_v1alpha1_ErrorPages_To_config_ErrorPages is an autogenerated conversion function.
+func Convert_v1alpha1_ErrorPages_To_config_ErrorPages(in *ErrorPages, out *config.ErrorPages, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ErrorPages_To_config_ErrorPages(in, out, s)
+}
+
+func autoConvert_config_ErrorPages_To_v1alpha1_ErrorPages(in *config.ErrorPages, out *ErrorPages, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_config_ErrorPages_To_v1alpha1_ErrorPages is an autogenerated conversion function.
+func Convert_config_ErrorPages_To_v1alpha1_ErrorPages(in *config.ErrorPages, out *ErrorPages, s conversion.Scope) error {
+	return autoConvert_config_ErrorPages_To_v1alpha1_ErrorPages(in, out, s)
+}
+
+func autoConvert_v1alpha1_ForwardAuth_To_config_ForwardAuth(in *ForwardAuth, out *config.ForwardAuth, s conversion.Scope) error {
+	out.Address = in.Address
+	out.AuthResponseHeaders = *(*[]string)(unsafe.Pointer(&in.AuthResponseHeaders))
+	return nil
+}
+
+// Convert_v1alpha1_ForwardAuth_To_config_ForwardAuth is an autogenerated conversion function.
+func Convert_v1alpha1_ForwardAuth_To_config_ForwardAuth(in *ForwardAuth, out *config.ForwardAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ForwardAuth_To_config_ForwardAuth(in, out, s)
+}
+
+func autoConvert_config_ForwardAuth_To_v1alpha1_ForwardAuth(in *config.ForwardAuth, out *ForwardAuth, s conversion.Scope) error {
+	out.Address = in.Address
+	out.AuthResponseHeaders = *(*[]string)(unsafe.Pointer(&in.AuthResponseHeaders))
+	return nil
+}
+
+// Convert_config_ForwardAuth_To_v1alpha1_ForwardAuth is an autogenerated conversion function.
+func Convert_config_ForwardAuth_To_v1alpha1_ForwardAuth(in *config.ForwardAuth, out *ForwardAuth, s conversion.Scope) error {
+	return autoConvert_config_ForwardAuth_To_v1alpha1_ForwardAuth(in, out, s)
+}
+
+func autoConvert_v1alpha1_Hibernation_To_config_Hibernation(in *Hibernation, out *config.Hibernation, s conversion.Scope) error {
+	out.DNSRecordPolicy = config.DNSRecordPolicy(in.DNSRecordPolicy)
+	return nil
+}
+
+// Convert_v1alpha1_Hibernation_To_config_Hibernation is an autogenerated conversion function.
+func Convert_v1alpha1_Hibernation_To_config_Hibernation(in *Hibernation, out *config.Hibernation, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Hibernation_To_config_Hibernation(in, out, s)
+}
+
+func autoConvert_config_Hibernation_To_v1alpha1_Hibernation(in *config.Hibernation, out *Hibernation, s conversion.Scope) error {
+	out.DNSRecordPolicy = DNSRecordPolicy(in.DNSRecordPolicy)
+	return nil
+}
+
+// Convert_config_Hibernation_To_v1alpha1_Hibernation is an autogenerated conversion function.
+func Convert_config_Hibernation_To_v1alpha1_Hibernation(in *config.Hibernation, out *Hibernation, s conversion.Scope) error {
+	return autoConvert_config_Hibernation_To_v1alpha1_Hibernation(in, out, s)
+}
+
+func autoConvert_v1alpha1_IPAllowList_To_config_IPAllowList(in *IPAllowList, out *config.IPAllowList, s conversion.Scope) error {
+	out.SourceRange = *(*[]string)(unsafe.Pointer(&in.SourceRange))
+	return nil
+}
+
+// Convert_v1alpha1_IPAllowList_To_config_IPAllowList is an autogenerated conversion function.
+func Convert_v1alpha1_IPAllowList_To_config_IPAllowList(in *IPAllowList, out *config.IPAllowList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_IPAllowList_To_config_IPAllowList(in, out, s)
+}
+
+func autoConvert_config_IPAllowList_To_v1alpha1_IPAllowList(in *config.IPAllowList, out *IPAllowList, s conversion.Scope) error {
+	out.SourceRange = *(*[]string)(unsafe.Pointer(&in.SourceRange))
+	return nil
+}
+
+// Convert_config_IPAllowList_To_v1alpha1_IPAllowList is an autogenerated conversion function.
+func Convert_config_IPAllowList_To_v1alpha1_IPAllowList(in *config.IPAllowList, out *IPAllowList, s conversion.Scope) error {
+	return autoConvert_config_IPAllowList_To_v1alpha1_IPAllowList(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy(in *NetworkPolicy, out *config.NetworkPolicy, s conversion.Scope) error {
+	out.Mode = config.NetworkPolicyMode(in.Mode)
+	out.BackendNamespaceSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.BackendNamespaceSelector))
+	out.MonitoringNamespace = in.MonitoringNamespace
+	return nil
+}
+
+// Convert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy(in *NetworkPolicy, out *config.NetworkPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkPolicy_To_config_NetworkPolicy(in, out, s)
+}
+
+func autoConvert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy(in *config.NetworkPolicy, out *NetworkPolicy, s conversion.Scope) error {
+	out.Mode = NetworkPolicyMode(in.Mode)
+	out.BackendNamespaceSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.BackendNamespaceSelector))
+	out.MonitoringNamespace = in.MonitoringNamespace
+	return nil
+}
+
+// Convert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy is an autogenerated conversion function.
+func Convert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy(in *config.NetworkPolicy, out *NetworkPolicy, s conversion.Scope) error {
+	return autoConvert_config_NetworkPolicy_To_v1alpha1_NetworkPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_OperatorDefaults_To_config_OperatorDefaults(in *OperatorDefaults, out *config.OperatorDefaults, s conversion.Scope) error {
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	out.Resources = (*v1.ResourceRequirements)(unsafe.Pointer(in.Resources))
+	out.LogLevel = in.LogLevel
+	out.IngressProvider = config.IngressProviderType(in.IngressProvider)
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_v1alpha1_OperatorDefaults_To_config_OperatorDefaults is an autogenerated conversion function.
+func Convert_v1alpha1_OperatorDefaults_To_config_OperatorDefaults(in *OperatorDefaults, out *config.OperatorDefaults, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OperatorDefaults_To_config_OperatorDefaults(in, out, s)
+}
+
+func autoConvert_config_OperatorDefaults_To_v1alpha1_OperatorDefaults(in *config.OperatorDefaults, out *OperatorDefaults, s conversion.Scope) error {
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	out.Resources = (*v1.ResourceRequirements)(unsafe.Pointer(in.Resources))
+	out.LogLevel = in.LogLevel
+	out.IngressProvider = IngressProviderType(in.IngressProvider)
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_config_OperatorDefaults_To_v1alpha1_OperatorDefaults is an autogenerated conversion function.
+func Convert_config_OperatorDefaults_To_v1alpha1_OperatorDefaults(in *config.OperatorDefaults, out *OperatorDefaults, s conversion.Scope) error {
+	return autoConvert_config_OperatorDefaults_To_v1alpha1_OperatorDefaults(in, out, s)
+}
+
+func autoConvert_v1alpha1_OperatorFeatures_To_config_OperatorFeatures(in *OperatorFeatures, out *config.OperatorFeatures, s conversion.Scope) error {
+	out.Dashboard = (*bool)(unsafe.Pointer(in.Dashboard))
+	return nil
+}
+
+// Convert_v1alpha1_OperatorFeatures_To_config_OperatorFeatures is an autogenerated conversion function.
+func Convert_v1alpha1_OperatorFeatures_To_config_OperatorFeatures(in *OperatorFeatures, out *config.OperatorFeatures, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OperatorFeatures_To_config_OperatorFeatures(in, out, s)
+}
+
+func autoConvert_config_OperatorFeatures_To_v1alpha1_OperatorFeatures(in *config.OperatorFeatures, out *OperatorFeatures, s conversion.Scope) error {
+	out.Dashboard = (*bool)(unsafe.Pointer(in.Dashboard))
+	return nil
+}
+
+// Convert_config_OperatorFeatures_To_v1alpha1_OperatorFeatures is an autogenerated conversion function.
+func Convert_config_OperatorFeatures_To_v1alpha1_OperatorFeatures(in *config.OperatorFeatures, out *OperatorFeatures, s conversion.Scope) error {
+	return autoConvert_config_OperatorFeatures_To_v1alpha1_OperatorFeatures(in, out, s)
+}
+
+func autoConvert_v1alpha1_OperatorLimits_To_config_OperatorLimits(in *OperatorLimits, out *config.OperatorLimits, s conversion.Scope) error {
+	out.MaxReplicas = (*int32)(unsafe.Pointer(in.MaxReplicas))
+	out.AllowedIngressProviders = *(*[]config.IngressProviderType)(unsafe.Pointer(&in.AllowedIngressProviders))
+	out.AllowedArgumentPrefixes = *(*[]string)(unsafe.Pointer(&in.AllowedArgumentPrefixes))
+	return nil
+}
+
+// Convert_v1alpha1_OperatorLimits_To_config_OperatorLimits is an autogenerated conversion function.
+func Convert_v1alpha1_OperatorLimits_To_config_OperatorLimits(in *OperatorLimits, out *config.OperatorLimits, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OperatorLimits_To_config_OperatorLimits(in, out, s)
+}
+
+func autoConvert_config_OperatorLimits_To_v1alpha1_OperatorLimits(in *config.OperatorLimits, out *OperatorLimits, s conversion.Scope) error {
+	out.MaxReplicas = (*int32)(unsafe.Pointer(in.MaxReplicas))
+	out.AllowedIngressProviders = *(*[]IngressProviderType)(unsafe.Pointer(&in.AllowedIngressProviders))
+	out.AllowedArgumentPrefixes = *(*[]string)(unsafe.Pointer(&in.AllowedArgumentPrefixes))
+	return nil
+}
+
+// Convert_config_OperatorLimits_To_v1alpha1_OperatorLimits is an autogenerated conversion function.
+func Convert_config_OperatorLimits_To_v1alpha1_OperatorLimits(in *config.OperatorLimits, out *OperatorLimits, s conversion.Scope) error {
+	return autoConvert_config_OperatorLimits_To_v1alpha1_OperatorLimits(in, out, s)
+}
+
+func autoConvert_v1alpha1_RateLimit_To_config_RateLimit(in *RateLimit, out *config.RateLimit, s conversion.Scope) error {
+	out.Average = in.Average
+	out.Burst = (*int64)(unsafe.Pointer(in.Burst))
+	out.Period = (*metav1.Duration)(unsafe.Pointer(in.Period))
+	return nil
+}
+
+// Convert_v1alpha1_RateLimit_To_config_RateLimit is an autogenerated conversion function.
+func Convert_v1alpha1_RateLimit_To_config_RateLimit(in *RateLimit, out *config.RateLimit, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RateLimit_To_config_RateLimit(in, out, s)
+}
+
+func autoConvert_config_RateLimit_To_v1alpha1_RateLimit(in *config.RateLimit, out *RateLimit, s conversion.Scope) error {
+	out.Average = in.Average
+	out.Burst = (*int64)(unsafe.Pointer(in.Burst))
+	out.Period = (*metav1.Duration)(unsafe.Pointer(in.Period))
+	return nil
+}
+
+// Convert_config_RateLimit_To_v1alpha1_RateLimit is an autogenerated conversion function.
+func Convert_config_RateLimit_To_v1alpha1_RateLimit(in *config.RateLimit, out *RateLimit, s conversion.Scope) error {
+	return autoConvert_config_RateLimit_To_v1alpha1_RateLimit(in, out, s)
+}
+
+func autoConvert_v1alpha1_RollbackStatus_To_config_RollbackStatus(in *RollbackStatus, out *config.RollbackStatus, s conversion.Scope) error {
+	if in.FailedConfig != nil {
+		in, out := &in.FailedConfig, &out.FailedConfig
+		*out = new(config.TraefikConfigSpec)
+		if err := Convert_v1alpha1_TraefikConfigSpec_To_config_TraefikConfigSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.FailedConfig = nil
+	}
+	out.Reason = in.Reason
+	out.Time = in.Time
+	return nil
+}
+
+// Convert_v1alpha1_RollbackStatus_To_config_RollbackStatus is an autogenerated conversion function.
+func Convert_v1alpha1_RollbackStatus_To_config_RollbackStatus(in *RollbackStatus, out *config.RollbackStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RollbackStatus_To_config_RollbackStatus(in, out, s)
+}
+
+func autoConvert_config_RollbackStatus_To_v1alpha1_RollbackStatus(in *config.RollbackStatus, out *RollbackStatus, s conversion.Scope) error {
+	if in.FailedConfig != nil {
+		in, out := &in.FailedConfig, &out.FailedConfig
+		*out = new(TraefikConfigSpec)
+		if err := Convert_config_TraefikConfigSpec_To_v1alpha1_TraefikConfigSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.FailedConfig = nil
+	}
+	out.Reason = in.Reason
+	out.Time = in.Time
+	return nil
+}
+
+// Convert_config_RollbackStatus_To_v1alpha1_RollbackStatus is an autogenerated conversion function.
+func Convert_config_RollbackStatus_To_v1alpha1_RollbackStatus(in *config.RollbackStatus, out *RollbackStatus, s conversion.Scope) error {
+	return autoConvert_config_RollbackStatus_To_v1alpha1_RollbackStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_Rollout_To_config_Rollout(in *Rollout, out *config.Rollout, s conversion.Scope) error {
+	out.MaxUnavailable = (*intstr.IntOrString)(unsafe.Pointer(in.MaxUnavailable))
+	out.MaxSurge = (*intstr.IntOrString)(unsafe.Pointer(in.MaxSurge))
+	out.ShutdownDelay = (*metav1.Duration)(unsafe.Pointer(in.ShutdownDelay))
+	return nil
+}
+
+// Convert_v1alpha1_Rollout_To_config_Rollout is an autogenerated conversion function.
+func Convert_v1alpha1_Rollout_To_config_Rollout(in *Rollout, out *config.Rollout, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Rollout_To_config_Rollout(in, out, s)
+}
+
+func autoConvert_config_Rollout_To_v1alpha1_Rollout(in *config.Rollout, out *Rollout, s conversion.Scope) error {
+	out.MaxUnavailable = (*intstr.IntOrString)(unsafe.Pointer(in.MaxUnavailable))
+	out.MaxSurge = (*intstr.IntOrString)(unsafe.Pointer(in.MaxSurge))
+	out.ShutdownDelay = (*metav1.Duration)(unsafe.Pointer(in.ShutdownDelay))
+	return nil
+}
+
+// Convert_config_Rollout_To_v1alpha1_Rollout is an autogenerated conversion function.
+func Convert_config_Rollout_To_v1alpha1_Rollout(in *config.Rollout, out *Rollout, s conversion.Scope) error {
+	return autoConvert_config_Rollout_To_v1alpha1_Rollout(in, out, s)
+}
+
+func autoConvert_v1alpha1_Scheduling_To_config_Scheduling(in *Scheduling, out *config.Scheduling, s conversion.Scope) error {
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.PriorityClassName = in.PriorityClassName
+	return nil
+}
+
+// Convert_v1alpha1_Scheduling_To_config_Scheduling is an autogenerated conversion function.
+func Convert_v1alpha1_Scheduling_To_config_Scheduling(in *Scheduling, out *config.Scheduling, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Scheduling_To_config_Scheduling(in, out, s)
+}
+
+func autoConvert_config_Scheduling_To_v1alpha1_Scheduling(in *config.Scheduling, out *Scheduling, s conversion.Scope) error {
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.PriorityClassName = in.PriorityClassName
+	return nil
+}
+
+// Convert_config_Scheduling_To_v1alpha1_Scheduling is an autogenerated conversion function.
+func Convert_config_Scheduling_To_v1alpha1_Scheduling(in *config.Scheduling, out *Scheduling, s conversion.Scope) error {
+	return autoConvert_config_Scheduling_To_v1alpha1_Scheduling(in, out, s)
+}
+
+func autoConvert_v1alpha1_SecureDashboard_To_config_SecureDashboard(in *SecureDashboard, out *config.SecureDashboard, s conversion.Scope) error {
+	out.Auth = config.DashboardAuthType(in.Auth)
+	out.ForwardAuth = (*config.ForwardAuth)(unsafe.Pointer(in.ForwardAuth))
+	return nil
+}
+
+// Convert_v1alpha1_SecureDashboard_To_config_SecureDashboard is an autogenerated conversion function.
+func Convert_v1alpha1_SecureDashboard_To_config_SecureDashboard(in *SecureDashboard, out *config.SecureDashboard, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SecureDashboard_To_config_SecureDashboard(in, out, s)
+}
+
+func autoConvert_config_SecureDashboard_To_v1alpha1_SecureDashboard(in *config.SecureDashboard, out *SecureDashboard, s conversion.Scope) error {
+	out.Auth = DashboardAuthType(in.Auth)
+	out.ForwardAuth = (*ForwardAuth)(unsafe.Pointer(in.ForwardAuth))
+	return nil
+}
+
+// Convert_config_SecureDashboard_To_v1alpha1_SecureDashboard is an autogenerated conversion function.
+func Convert_config_SecureDashboard_To_v1alpha1_SecureDashboard(in *config.SecureDashboard, out *SecureDashboard, s conversion.Scope) error {
+	return autoConvert_config_SecureDashboard_To_v1alpha1_SecureDashboard(in, out, s)
+}
+
+func autoConvert_v1alpha1_SecurityHeaders_To_config_SecurityHeaders(in *SecurityHeaders, out *config.SecurityHeaders, s conversion.Scope) error {
+	out.STSSeconds = (*int64)(unsafe.Pointer(in.STSSeconds))
+	out.STSIncludeSubdomains = in.STSIncludeSubdomains
+	out.STSPreload = in.STSPreload
+	out.ReferrerPolicy = in.ReferrerPolicy
+	return nil
+}
+
+// Convert_v1alpha1_SecurityHeaders_To_config_SecurityHeaders is an autogenerated conversion function.
+func Convert_v1alpha1_SecurityHeaders_To_config_SecurityHeaders(in *SecurityHeaders, out *config.SecurityHeaders, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SecurityHeaders_To_config_SecurityHeaders(in, out, s)
+}
+
+func autoConvert_config_SecurityHeaders_To_v1alpha1_SecurityHeaders(in *config.SecurityHeaders, out *SecurityHeaders, s conversion.Scope) error {
+	out.STSSeconds = (*int64)(unsafe.Pointer(in.STSSeconds))
+	out.STSIncludeSubdomains = in.STSIncludeSubdomains
+	out.STSPreload = in.STSPreload
+	out.ReferrerPolicy = in.ReferrerPolicy
+	return nil
+}
+
+// Convert_config_SecurityHeaders_To_v1alpha1_SecurityHeaders is an autogenerated conversion function.
+func Convert_config_SecurityHeaders_To_v1alpha1_SecurityHeaders(in *config.SecurityHeaders, out *SecurityHeaders, s conversion.Scope) error {
+	return autoConvert_config_SecurityHeaders_To_v1alpha1_SecurityHeaders(in, out, s)
+}
+
 func autoConvert_v1alpha1_TraefikConfig_To_config_TraefikConfig(in *TraefikConfig, out *config.TraefikConfig, s conversion.Scope) error {
 	if err := Convert_v1alpha1_TraefikConfigSpec_To_config_TraefikConfigSpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
@@ -70,6 +641,21 @@ func autoConvert_v1alpha1_TraefikConfigSpec_To_config_TraefikConfigSpec(in *Trae
 	out.IngressProvider = config.IngressProviderType(in.IngressProvider)
 	out.LogLevel = in.LogLevel
 	out.Dashboard = in.Dashboard
+	out.SecureDashboard = (*config.SecureDashboard)(unsafe.Pointer(in.SecureDashboard))
+	out.Version = in.Version
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = in.LabelSelector
+	out.NetworkPolicy = (*config.NetworkPolicy)(unsafe.Pointer(in.NetworkPolicy))
+	out.DefaultMiddlewares = (*config.DefaultMiddlewares)(unsafe.Pointer(in.DefaultMiddlewares))
+	out.ErrorPages = (*config.ErrorPages)(unsafe.Pointer(in.ErrorPages))
+	out.AdditionalArguments = *(*[]string)(unsafe.Pointer(&in.AdditionalArguments))
+	out.DynamicConfig = (*config.DynamicConfig)(unsafe.Pointer(in.DynamicConfig))
+	out.Scheduling = (*config.Scheduling)(unsafe.Pointer(in.Scheduling))
+	out.DeploymentMode = config.DeploymentMode(in.DeploymentMode)
+	out.HostPort = in.HostPort
+	out.HostNetwork = in.HostNetwork
+	out.Rollout = (*config.Rollout)(unsafe.Pointer(in.Rollout))
+	out.Hibernation = (*config.Hibernation)(unsafe.Pointer(in.Hibernation))
 	return nil
 }
 
@@ -83,6 +669,21 @@ func autoConvert_config_TraefikConfigSpec_To_v1alpha1_TraefikConfigSpec(in *conf
 	out.IngressProvider = IngressProviderType(in.IngressProvider)
 	out.LogLevel = in.LogLevel
 	out.Dashboard = in.Dashboard
+	out.SecureDashboard = (*SecureDashboard)(unsafe.Pointer(in.SecureDashboard))
+	out.Version = in.Version
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = in.LabelSelector
+	out.NetworkPolicy = (*NetworkPolicy)(unsafe.Pointer(in.NetworkPolicy))
+	out.DefaultMiddlewares = (*DefaultMiddlewares)(unsafe.Pointer(in.DefaultMiddlewares))
+	out.ErrorPages = (*ErrorPages)(unsafe.Pointer(in.ErrorPages))
+	out.AdditionalArguments = *(*[]string)(unsafe.Pointer(&in.AdditionalArguments))
+	out.DynamicConfig = (*DynamicConfig)(unsafe.Pointer(in.DynamicConfig))
+	out.Scheduling = (*Scheduling)(unsafe.Pointer(in.Scheduling))
+	out.DeploymentMode = DeploymentMode(in.DeploymentMode)
+	out.HostPort = in.HostPort
+	out.HostNetwork = in.HostNetwork
+	out.Rollout = (*Rollout)(unsafe.Pointer(in.Rollout))
+	out.Hibernation = (*Hibernation)(unsafe.Pointer(in.Hibernation))
 	return nil
 }
 
@@ -90,3 +691,75 @@ func autoConvert_config_TraefikConfigSpec_To_v1alpha1_TraefikConfigSpec(in *conf
 func Convert_config_TraefikConfigSpec_To_v1alpha1_TraefikConfigSpec(in *config.TraefikConfigSpec, out *TraefikConfigSpec, s conversion.Scope) error {
 	return autoConvert_config_TraefikConfigSpec_To_v1alpha1_TraefikConfigSpec(in, out, s)
 }
+
+func autoConvert_v1alpha1_TraefikOperatorConfiguration_To_config_TraefikOperatorConfiguration(in *TraefikOperatorConfiguration, out *config.TraefikOperatorConfiguration, s conversion.Scope) error {
+	if err := Convert_v1alpha1_OperatorDefaults_To_config_OperatorDefaults(&in.Defaults, &out.Defaults, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_OperatorLimits_To_config_OperatorLimits(&in.Limits, &out.Limits, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_OperatorFeatures_To_config_OperatorFeatures(&in.Features, &out.Features, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_TraefikOperatorConfiguration_To_config_TraefikOperatorConfiguration is an autogenerated conversion function.
+func Convert_v1alpha1_TraefikOperatorConfiguration_To_config_TraefikOperatorConfiguration(in *TraefikOperatorConfiguration, out *config.TraefikOperatorConfiguration, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TraefikOperatorConfiguration_To_config_TraefikOperatorConfiguration(in, out, s)
+}
+
+func autoConvert_config_TraefikOperatorConfiguration_To_v1alpha1_TraefikOperatorConfiguration(in *config.TraefikOperatorConfiguration, out *TraefikOperatorConfiguration, s conversion.Scope) error {
+	if err := Convert_config_OperatorDefaults_To_v1alpha1_OperatorDefaults(&in.Defaults, &out.Defaults, s); err != nil {
+		return err
+	}
+	if err := Convert_config_OperatorLimits_To_v1alpha1_OperatorLimits(&in.Limits, &out.Limits, s); err != nil {
+		return err
+	}
+	if err := Convert_config_OperatorFeatures_To_v1alpha1_OperatorFeatures(&in.Features, &out.Features, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_TraefikOperatorConfiguration_To_v1alpha1_TraefikOperatorConfiguration is an autogenerated conversion function.
+func Convert_config_TraefikOperatorConfiguration_To_v1alpha1_TraefikOperatorConfiguration(in *config.TraefikOperatorConfiguration, out *TraefikOperatorConfiguration, s conversion.Scope) error {
+	return autoConvert_config_TraefikOperatorConfiguration_To_v1alpha1_TraefikOperatorConfiguration(in, out, s)
+}
+
+func autoConvert_v1alpha1_TraefikStatus_To_config_TraefikStatus(in *TraefikStatus, out *config.TraefikStatus, s conversion.Scope) error {
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(config.RollbackStatus)
+		if err := Convert_v1alpha1_RollbackStatus_To_config_RollbackStatus(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Rollback = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_TraefikStatus_To_config_TraefikStatus is an autogenerated conversion function.
+func Convert_v1alpha1_TraefikStatus_To_config_TraefikStatus(in *TraefikStatus, out *config.TraefikStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TraefikStatus_To_config_TraefikStatus(in, out, s)
+}
+
+func autoConvert_config_TraefikStatus_To_v1alpha1_TraefikStatus(in *config.TraefikStatus, out *TraefikStatus, s conversion.Scope) error {
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RollbackStatus)
+		if err := Convert_config_RollbackStatus_To_v1alpha1_RollbackStatus(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Rollback = nil
+	}
+	return nil
+}
+
+// Convert_config_TraefikStatus_To_v1alpha1_TraefikStatus is an autogenerated conversion function.
+func Convert_config_TraefikStatus_To_v1alpha1_TraefikStatus(in *config.TraefikStatus, out *TraefikStatus, s conversion.Scope) error {
+	return autoConvert_config_TraefikStatus_To_v1alpha1_TraefikStatus(in, out, s)
+}
@@ -5,7 +5,9 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // IngressProviderType defines the type of Kubernetes Ingress provider to use.
@@ -46,6 +48,322 @@ type TraefikConfigSpec struct {
 	// configuration elements, including sensitive data, for which access should be reserved to administrators.
 	// Defaults to false if not specified.
 	Dashboard bool `json:"dashboard,omitempty"`
+
+	// SecureDashboard publishes the dashboard through an authenticated
+	// IngressRoute on "dashboard.ingress.<shoot domain>" instead of the
+	// insecure API port. It requires the dashboard to be enabled and a DNS
+	// domain for the shoot.
+	SecureDashboard *SecureDashboard `json:"secureDashboard,omitempty"`
+
+	// Version pins the Traefik version deployed to the shoot cluster, e.g.
+	// "v3.6.11". The version must be one of the versions offered by the
+	// extension. Defaults to the default version of the extension if
+	// not specified.
+	Version string `json:"version,omitempty"`
+
+	// Namespaces restricts Traefik to watch Ingress resources in the given
+	// namespaces only. All namespaces are watched if empty. The
+	// KubernetesIngressNGINX provider supports a single namespace only.
+	//
+	// If namespaces are specified, Traefik is only allowed to read secrets
+	// within these namespaces. The namespaces must exist in the shoot
+	// cluster.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// LabelSelector restricts Traefik to watch Ingress resources matching the
+	// given label selector only, e.g. "app=foo". All Ingress resources are
+	// watched if empty. Label selectors are not supported by the
+	// KubernetesIngressNGINX provider.
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// NetworkPolicy configures the NetworkPolicy of Traefik.
+	// Defaults to the "Open" mode if not specified.
+	NetworkPolicy *NetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// DefaultMiddlewares configures middlewares, which are applied to all
+	// requests of the web and websecure entrypoints. Every Ingress inherits
+	// them without any changes.
+	DefaultMiddlewares *DefaultMiddlewares `json:"defaultMiddlewares,omitempty"`
+
+	// ErrorPages deploys a default backend, which serves error pages for
+	// requests without a matching router and for responses with a 5xx
+	// status code.
+	ErrorPages *ErrorPages `json:"errorPages,omitempty"`
+
+	// AdditionalArguments are passed to Traefik in addition to the arguments
+	// managed by the extension, e.g. "--accesslog=true". Each argument must
+	// start with one of the prefixes allowed by the operator of the
+	// extension. Arguments of the entrypoints, providers, ping, metrics, API
+	// and log level are managed by the extension and cannot be overridden.
+	AdditionalArguments []string `json:"additionalArguments,omitempty"`
+
+	// DynamicConfig enables the file provider of Traefik with the dynamic
+	// configuration of a ConfigMap in the shoot cluster.
+	DynamicConfig *DynamicConfig `json:"dynamicConfig,omitempty"`
+
+	// Scheduling configures the scheduling of the Traefik pods, e.g. to pin
+	// them to a dedicated worker pool.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
+	// DeploymentMode specifies how Traefik is deployed to the shoot cluster.
+	// Valid values are:
+	// - "Deployment" (default): A Deployment behind a LoadBalancer Service
+	// - "DaemonSet": A pod on every eligible node behind a NodePort Service
+	//
+	// Use DaemonSet for shoots without a working LoadBalancer Service, e.g.
+	// on local or bare metal providers. The ingress DNS record points to the
+	// external IPs of the nodes running Traefik instead of a load balancer, or
+	// to their internal IPs, if none of them has an external IP.
+	DeploymentMode DeploymentMode `json:"deploymentMode,omitempty"`
+
+	// HostPort binds the web and websecure entrypoints to the ports 80 and
+	// 443 of the nodes. It is only supported in the DaemonSet mode.
+	HostPort bool `json:"hostPort,omitempty"`
+
+	// HostNetwork runs the Traefik pods in the network namespace of the
+	// nodes, so that the web and websecure entrypoints listen on the ports
+	// 8000 and 8443 of the nodes. NetworkPolicies do not apply to pods in
+	// the host network. It is only supported in the DaemonSet mode and
+	// cannot be combined with HostPort.
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// Rollout configures the rolling update and the graceful shutdown of
+	// the Traefik pods.
+	Rollout *Rollout `json:"rollout,omitempty"`
+
+	// Hibernation configures the handling of the ingress DNS record, while
+	// the shoot is hibernated. Traefik is scaled to zero during hibernation
+	// and restored, when the shoot is woken up.
+	Hibernation *Hibernation `json:"hibernation,omitempty"`
+}
+
+// DefaultMiddlewares configures the middlewares, which are applied to all
+// requests of the web and websecure entrypoints.
+type DefaultMiddlewares struct {
+	// SecurityHeaders adds HSTS and other security headers to all responses.
+	SecurityHeaders *SecurityHeaders `json:"securityHeaders,omitempty"`
+
+	// RateLimit limits the rate of requests per client IP.
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// IPAllowList restricts access to the given client IP ranges.
+	IPAllowList *IPAllowList `json:"ipAllowList,omitempty"`
+
+	// MaxRequestBodyBytes is the maximum size of request bodies in bytes.
+	// Requests with larger bodies are rejected.
+	MaxRequestBodyBytes *int64 `json:"maxRequestBodyBytes,omitempty"`
+}
+
+// SecurityHeaders configures the security headers middleware.
+type SecurityHeaders struct {
+	// STSSeconds is the max-age of the Strict-Transport-Security header.
+	// Defaults to 31536000 (one year) if not specified.
+	STSSeconds *int64 `json:"stsSeconds,omitempty"`
+
+	// STSIncludeSubdomains adds the includeSubDomains directive to the
+	// Strict-Transport-Security header.
+	STSIncludeSubdomains bool `json:"stsIncludeSubdomains,omitempty"`
+
+	// STSPreload adds the preload directive to the Strict-Transport-Security
+	// header.
+	STSPreload bool `json:"stsPreload,omitempty"`
+
+	// ReferrerPolicy is the value of the Referrer-Policy header.
+	// Defaults to "strict-origin-when-cross-origin" if not specified.
+	ReferrerPolicy string `json:"referrerPolicy,omitempty"`
+}
+
+// RateLimit configures the rate limit middleware.
+type RateLimit struct {
+	// Average is the number of requests per period, which are allowed on
+	// average per client IP.
+	Average int64 `json:"average"`
+
+	// Burst is the maximum number of requests, which are allowed in a burst.
+	// Defaults to the average if not specified.
+	Burst *int64 `json:"burst,omitempty"`
+
+	// Period is the period of the average rate.
+	// Defaults to 1s if not specified.
+	Period *metav1.Duration `json:"period,omitempty"`
+}
+
+// IPAllowList configures the IP allowlist middleware.
+type IPAllowList struct {
+	// SourceRange are the allowed client IPs or CIDR ranges.
+	SourceRange []string `json:"sourceRange"`
+}
+
+// ErrorPages configures the error pages of the default backend.
+type ErrorPages struct {
+	// ConfigMapName is the name of a ConfigMap in the kube-system namespace
+	// of the shoot cluster, which contains the error pages. The page of a
+	// status code is taken from the key "<status code>.html", e.g.
+	// "503.html". The keys "404.html" and "5xx.html" are used as fallbacks
+	// for requests without a matching router and for 5xx responses.
+	// The error pages of the extension are used if not specified.
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// DynamicConfig configures the dynamic configuration of the file provider.
+type DynamicConfig struct {
+	// ConfigMapName is the name of a ConfigMap in the kube-system namespace
+	// of the shoot cluster, which contains Traefik dynamic configuration
+	// files. The keys of the ConfigMap must have a ".yaml", ".yml" or
+	// ".toml" extension. Changes to the ConfigMap are picked up by Traefik
+	// without a restart, but they are only validated by the extension
+	// during reconciliation.
+	ConfigMapName string `json:"configMapName"`
+}
+
+// Scheduling configures the scheduling of the Traefik pods.
+type Scheduling struct {
+	// NodeSelector restricts the Traefik pods to nodes with the given labels.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow the Traefik pods to be scheduled onto nodes with
+	// matching taints, e.g. of a dedicated ingress worker pool.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity configures the node and pod affinity of the Traefik pods.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the Traefik
+	// pods. Defaults to "system-cluster-critical" if not specified, so that
+	// Traefik is not preempted or evicted before the workloads of the shoot.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// DashboardAuthType defines how access to the secure dashboard is
+// authenticated.
+type DashboardAuthType string
+
+const (
+	// DashboardAuthBasicAuth protects the dashboard with BasicAuth
+	// credentials, which are generated by the extension.
+	DashboardAuthBasicAuth DashboardAuthType = "BasicAuth"
+	// DashboardAuthOIDC protects the dashboard with a forward-auth middleware,
+	// which delegates the authentication to an OIDC proxy.
+	DashboardAuthOIDC DashboardAuthType = "OIDC"
+)
+
+// SecureDashboard configures the secure exposure of the Traefik dashboard.
+type SecureDashboard struct {
+	// Auth is the authentication of the dashboard.
+	// Valid values are:
+	// - "BasicAuth" (default): Credentials generated by the extension, which are
+	//   stored in the Secret "<shoot name>.traefik-dashboard" of the project namespace
+	// - "OIDC": Forward-auth middleware delegating to an OIDC proxy, e.g. oauth2-proxy
+	Auth DashboardAuthType `json:"auth,omitempty"`
+
+	// ForwardAuth configures the forward-auth middleware of the "OIDC"
+	// authentication.
+	ForwardAuth *ForwardAuth `json:"forwardAuth,omitempty"`
+}
+
+// ForwardAuth configures a forward-auth middleware.
+type ForwardAuth struct {
+	// Address is the URL of the authentication service, e.g.
+	// "https://oauth2-proxy.example.com/oauth2/auth".
+	Address string `json:"address"`
+
+	// AuthResponseHeaders are the headers of the authentication response,
+	// which are forwarded to the dashboard.
+	AuthResponseHeaders []string `json:"authResponseHeaders,omitempty"`
+}
+
+// Rollout configures the rolling update and the graceful shutdown of the
+// Traefik pods.
+type Rollout struct {
+	// MaxUnavailable is the maximum number of Traefik pods, which may be
+	// unavailable during a rolling update. Defaults to 0 if not specified,
+	// so that the serving capacity is kept during a rolling update. It is
+	// only supported in the Deployment mode.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MaxSurge is the maximum number of Traefik pods, which may be created
+	// in addition to the desired number of pods during a rolling update.
+	// Defaults to 1 if not specified. It is only supported in the
+	// Deployment mode.
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// ShutdownDelay is the duration, for which a terminating Traefik pod
+	// keeps accepting requests, while it is removed from the endpoints of
+	// the Traefik Service and the load balancer. Defaults to 15s if not
+	// specified.
+	ShutdownDelay *metav1.Duration `json:"shutdownDelay,omitempty"`
+}
+
+// Hibernation configures the handling of Traefik, while the shoot is
+// hibernated.
+type Hibernation struct {
+	// DNSRecordPolicy specifies, what happens with the ingress DNS record,
+	// while the shoot is hibernated.
+	// Valid values are:
+	// - "Delete" (default): The DNS record is deleted and re-created, once
+	//   the LoadBalancer of Traefik has an address after the wake-up
+	// - "Keep": The DNS record is kept, e.g. for providers, which keep the
+	//   address of the LoadBalancer during hibernation
+	DNSRecordPolicy DNSRecordPolicy `json:"dnsRecordPolicy,omitempty"`
+}
+
+// DNSRecordPolicy defines, what happens with the ingress DNS record, while
+// the shoot is hibernated.
+type DNSRecordPolicy string
+
+const (
+	// DNSRecordPolicyDelete deletes the ingress DNS record, while the shoot
+	// is hibernated.
+	DNSRecordPolicyDelete DNSRecordPolicy = "Delete"
+	// DNSRecordPolicyKeep keeps the ingress DNS record, while the shoot is
+	// hibernated.
+	DNSRecordPolicyKeep DNSRecordPolicy = "Keep"
+)
+
+// DeploymentMode defines how Traefik is deployed to the shoot cluster.
+type DeploymentMode string
+
+const (
+	// DeploymentModeDeployment deploys Traefik as a Deployment behind a
+	// LoadBalancer Service.
+	DeploymentModeDeployment DeploymentMode = "Deployment"
+	// DeploymentModeDaemonSet deploys Traefik as a DaemonSet behind a
+	// NodePort Service.
+	DeploymentModeDaemonSet DeploymentMode = "DaemonSet"
+)
+
+// NetworkPolicyMode defines how restrictive the NetworkPolicy of Traefik is.
+type NetworkPolicyMode string
+
+const (
+	// NetworkPolicyModeOpen allows all ingress traffic to Traefik and all
+	// egress traffic from Traefik to pods in all namespaces.
+	NetworkPolicyModeOpen NetworkPolicyMode = "Open"
+	// NetworkPolicyModeRestricted allows ingress traffic to the entrypoint
+	// ports of Traefik and to the metrics port from the monitoring namespace
+	// only. Egress traffic is allowed to the backends of Traefik only.
+	NetworkPolicyModeRestricted NetworkPolicyMode = "Restricted"
+)
+
+// NetworkPolicy configures the NetworkPolicy of Traefik.
+type NetworkPolicy struct {
+	// Mode is the mode of the NetworkPolicy.
+	// Valid values are:
+	// - "Open" (default): Allow all ingress traffic and egress traffic to all pods
+	// - "Restricted": Allow ingress traffic to the entrypoints and metrics only and
+	//   egress traffic to the backends only
+	Mode NetworkPolicyMode `json:"mode,omitempty"`
+
+	// BackendNamespaceSelector selects the namespaces, whose pods Traefik may
+	// reach in the "Restricted" mode. If not specified, the egress rules are
+	// derived from the backend services referenced by the Ingress and
+	// IngressRoute resources in the shoot cluster during reconciliation.
+	BackendNamespaceSelector *metav1.LabelSelector `json:"backendNamespaceSelector,omitempty"`
+
+	// MonitoringNamespace is the namespace, from which the metrics of Traefik
+	// may be scraped in the "Restricted" mode.
+	// Defaults to "monitoring" if not specified.
+	MonitoringNamespace string `json:"monitoringNamespace,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
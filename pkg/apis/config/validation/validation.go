@@ -0,0 +1,468 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package validation provides validation for the configuration API types.
+package validation
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+// reservedConfigMapNames are the names of the ConfigMaps, which are managed
+// by the extension in the kube-system namespace of the shoot cluster.
+var reservedConfigMapNames = sets.New("traefik-error-pages", "traefik-error-pages-nginx")
+
+// managedArgumentPrefixes are the prefixes of the Traefik arguments, which are
+// managed by the extension and cannot be passed as additional arguments.
+var managedArgumentPrefixes = []string{
+	"--entrypoints",
+	"--providers",
+	"--ping",
+	"--metrics",
+	"--api",
+	"--log.level",
+}
+
+// argumentNameRegexp matches the name of a Traefik argument.
+var argumentNameRegexp = regexp.MustCompile(`^--[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// ValidateTraefikConfig validates the given [config.TraefikConfig].
+func ValidateTraefikConfig(cfg *config.TraefikConfig) field.ErrorList {
+	return ValidateTraefikConfigSpec(&cfg.Spec, field.NewPath("spec"))
+}
+
+// ValidateTraefikConfigSpec validates the given [config.TraefikConfigSpec].
+func ValidateTraefikConfigSpec(spec *config.TraefikConfigSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	namespacesPath := fldPath.Child("namespaces")
+	seen := sets.New[string]()
+	for i, namespace := range spec.Namespaces {
+		for _, msg := range validation.IsDNS1123Label(namespace) {
+			allErrs = append(allErrs, field.Invalid(namespacesPath.Index(i), namespace, msg))
+		}
+		if seen.Has(namespace) {
+			allErrs = append(allErrs, field.Duplicate(namespacesPath.Index(i), namespace))
+		}
+		seen.Insert(namespace)
+	}
+
+	labelSelectorPath := fldPath.Child("labelSelector")
+	if spec.LabelSelector != "" {
+		if _, err := labels.Parse(spec.LabelSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(labelSelectorPath, spec.LabelSelector, err.Error()))
+		}
+	}
+
+	// The KubernetesIngressNGINX provider mimics the options of the NGINX
+	// Ingress controller, which supports watching a single namespace only and
+	// does not filter Ingress resources by labels.
+	if spec.IngressProvider == config.IngressProviderKubernetesIngressNGINX {
+		if len(spec.Namespaces) > 1 {
+			allErrs = append(allErrs, field.TooMany(namespacesPath, len(spec.Namespaces), 1))
+		}
+		if spec.LabelSelector != "" {
+			allErrs = append(allErrs, field.Forbidden(labelSelectorPath, "label selectors are not supported by the KubernetesIngressNGINX provider"))
+		}
+	}
+
+	if spec.SecureDashboard != nil {
+		secureDashboardPath := fldPath.Child("secureDashboard")
+		if !spec.Dashboard {
+			allErrs = append(allErrs, field.Forbidden(secureDashboardPath, "requires the dashboard to be enabled"))
+		}
+		allErrs = append(allErrs, validateSecureDashboard(spec.SecureDashboard, secureDashboardPath)...)
+	}
+
+	if spec.NetworkPolicy != nil {
+		allErrs = append(allErrs, validateNetworkPolicy(spec.NetworkPolicy, fldPath.Child("networkPolicy"))...)
+	}
+
+	if spec.DefaultMiddlewares != nil {
+		allErrs = append(allErrs, validateDefaultMiddlewares(spec.DefaultMiddlewares, fldPath.Child("defaultMiddlewares"))...)
+	}
+
+	if spec.ErrorPages != nil {
+		allErrs = append(allErrs, validateErrorPages(spec.ErrorPages, fldPath.Child("errorPages"))...)
+	}
+
+	allErrs = append(allErrs, validateAdditionalArguments(spec.AdditionalArguments, fldPath.Child("additionalArguments"))...)
+
+	if spec.DynamicConfig != nil {
+		allErrs = append(allErrs, validateDynamicConfig(spec.DynamicConfig, fldPath.Child("dynamicConfig"))...)
+	}
+
+	if spec.Scheduling != nil {
+		allErrs = append(allErrs, validateScheduling(spec.Scheduling, fldPath.Child("scheduling"))...)
+	}
+
+	allErrs = append(allErrs, validateDeploymentMode(spec, fldPath)...)
+
+	if spec.Rollout != nil {
+		allErrs = append(allErrs, validateRollout(spec.Rollout, spec.DeploymentMode == config.DeploymentModeDaemonSet, fldPath.Child("rollout"))...)
+	}
+
+	if spec.Hibernation != nil {
+		allErrs = append(allErrs, validateHibernation(spec.Hibernation, fldPath.Child("hibernation"))...)
+	}
+
+	return allErrs
+}
+
+// validateSecureDashboard validates the given [config.SecureDashboard].
+func validateSecureDashboard(dashboard *config.SecureDashboard, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	forwardAuthPath := fldPath.Child("forwardAuth")
+
+	switch dashboard.Auth {
+	case "", config.DashboardAuthBasicAuth:
+		if dashboard.ForwardAuth != nil {
+			allErrs = append(allErrs, field.Forbidden(forwardAuthPath, "is only supported for the OIDC authentication"))
+		}
+	case config.DashboardAuthOIDC:
+		if dashboard.ForwardAuth == nil {
+			allErrs = append(allErrs, field.Required(forwardAuthPath, "is required for the OIDC authentication"))
+
+			break
+		}
+
+		addressPath := forwardAuthPath.Child("address")
+		address, err := url.Parse(dashboard.ForwardAuth.Address)
+		if err != nil || (address.Scheme != "http" && address.Scheme != "https") || address.Host == "" {
+			allErrs = append(allErrs, field.Invalid(addressPath, dashboard.ForwardAuth.Address, "must be an absolute http or https URL"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("auth"), dashboard.Auth, []config.DashboardAuthType{
+			config.DashboardAuthBasicAuth,
+			config.DashboardAuthOIDC,
+		}))
+	}
+
+	return allErrs
+}
+
+// validateNetworkPolicy validates the given [config.NetworkPolicy].
+func validateNetworkPolicy(networkPolicy *config.NetworkPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch networkPolicy.Mode {
+	case "", config.NetworkPolicyModeOpen, config.NetworkPolicyModeRestricted:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), networkPolicy.Mode, []config.NetworkPolicyMode{
+			config.NetworkPolicyModeOpen,
+			config.NetworkPolicyModeRestricted,
+		}))
+	}
+
+	if networkPolicy.BackendNamespaceSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(
+			networkPolicy.BackendNamespaceSelector,
+			metav1validation.LabelSelectorValidationOptions{},
+			fldPath.Child("backendNamespaceSelector"),
+		)...)
+	}
+
+	if networkPolicy.MonitoringNamespace != "" {
+		for _, msg := range validation.IsDNS1123Label(networkPolicy.MonitoringNamespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("monitoringNamespace"), networkPolicy.MonitoringNamespace, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateDefaultMiddlewares validates the given [config.DefaultMiddlewares].
+func validateDefaultMiddlewares(middlewares *config.DefaultMiddlewares, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if headers := middlewares.SecurityHeaders; headers != nil {
+		if headers.STSSeconds != nil && *headers.STSSeconds < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("securityHeaders", "stsSeconds"), *headers.STSSeconds, "must not be negative"))
+		}
+	}
+
+	if rateLimit := middlewares.RateLimit; rateLimit != nil {
+		rateLimitPath := fldPath.Child("rateLimit")
+		if rateLimit.Average <= 0 {
+			allErrs = append(allErrs, field.Invalid(rateLimitPath.Child("average"), rateLimit.Average, "must be greater than zero"))
+		}
+		if rateLimit.Burst != nil && *rateLimit.Burst < 0 {
+			allErrs = append(allErrs, field.Invalid(rateLimitPath.Child("burst"), *rateLimit.Burst, "must not be negative"))
+		}
+		if rateLimit.Period != nil && rateLimit.Period.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(rateLimitPath.Child("period"), rateLimit.Period.Duration.String(), "must be greater than zero"))
+		}
+	}
+
+	if allowList := middlewares.IPAllowList; allowList != nil {
+		sourceRangePath := fldPath.Child("ipAllowList", "sourceRange")
+		if len(allowList.SourceRange) == 0 {
+			allErrs = append(allErrs, field.Required(sourceRangePath, "at least one IP or CIDR range is required"))
+		}
+		for i, sourceRange := range allowList.SourceRange {
+			if net.ParseIP(sourceRange) != nil {
+				continue
+			}
+			if _, _, err := net.ParseCIDR(sourceRange); err != nil {
+				allErrs = append(allErrs, field.Invalid(sourceRangePath.Index(i), sourceRange, "must be an IP address or a CIDR range"))
+			}
+		}
+	}
+
+	if middlewares.MaxRequestBodyBytes != nil && *middlewares.MaxRequestBodyBytes <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRequestBodyBytes"), *middlewares.MaxRequestBodyBytes, "must be greater than zero"))
+	}
+
+	return allErrs
+}
+
+// validateErrorPages validates the given [config.ErrorPages].
+func validateErrorPages(errorPages *config.ErrorPages, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if errorPages.ConfigMapName != "" {
+		allErrs = append(allErrs, validateConfigMapName(errorPages.ConfigMapName, fldPath.Child("configMapName"))...)
+	}
+
+	return allErrs
+}
+
+// validateDynamicConfig validates the given [config.DynamicConfig].
+func validateDynamicConfig(dynamicConfig *config.DynamicConfig, fldPath *field.Path) field.ErrorList {
+	configMapNamePath := fldPath.Child("configMapName")
+	if dynamicConfig.ConfigMapName == "" {
+		return field.ErrorList{field.Required(configMapNamePath, "the name of the ConfigMap with the dynamic configuration is required")}
+	}
+
+	return validateConfigMapName(dynamicConfig.ConfigMapName, configMapNamePath)
+}
+
+// validateConfigMapName validates the name of a ConfigMap of the shoot owner
+// in the kube-system namespace of the shoot cluster.
+func validateConfigMapName(name string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, msg := range validation.IsDNS1123Subdomain(name) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, msg))
+	}
+	if reservedConfigMapNames.Has(name) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "must not be the name of a ConfigMap managed by the extension"))
+	}
+
+	return allErrs
+}
+
+// validateAdditionalArguments validates the given additional arguments of
+// Traefik. The arguments must not conflict with the arguments managed by the
+// extension. The names of Traefik arguments are case-insensitive.
+func validateAdditionalArguments(args []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, arg := range args {
+		name, _, _ := strings.Cut(arg, "=")
+		if !argumentNameRegexp.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), arg, "must be of the form --<name> or --<name>=<value>"))
+
+			continue
+		}
+
+		name = strings.ToLower(name)
+		for _, prefix := range managedArgumentPrefixes {
+			if name == prefix || strings.HasPrefix(name, prefix+".") {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Index(i), "conflicts with the "+prefix+" arguments managed by the extension"))
+
+				break
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateDeploymentMode validates the deployment mode of the given
+// [config.TraefikConfigSpec] and the host options, which depend on it.
+func validateDeploymentMode(spec *config.TraefikConfigSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch spec.DeploymentMode {
+	case "", config.DeploymentModeDeployment:
+		if spec.HostPort {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("hostPort"), "is only supported in the DaemonSet deployment mode"))
+		}
+		if spec.HostNetwork {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("hostNetwork"), "is only supported in the DaemonSet deployment mode"))
+		}
+	case config.DeploymentModeDaemonSet:
+		if spec.HostPort && spec.HostNetwork {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("hostPort"), "cannot be combined with hostNetwork"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("deploymentMode"), spec.DeploymentMode, []config.DeploymentMode{
+			config.DeploymentModeDeployment,
+			config.DeploymentModeDaemonSet,
+		}))
+	}
+
+	return allErrs
+}
+
+// validateRollout validates the given [config.Rollout]. The rolling update
+// parameters are only supported in the Deployment mode, because the DaemonSet
+// is always updated one node at a time.
+func validateRollout(rollout *config.Rollout, daemonSet bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, param := range []struct {
+		name  string
+		value *intstr.IntOrString
+	}{
+		{"maxUnavailable", rollout.MaxUnavailable},
+		{"maxSurge", rollout.MaxSurge},
+	} {
+		if param.value == nil {
+			continue
+		}
+		if daemonSet {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(param.name), "is only supported in the Deployment mode"))
+
+			continue
+		}
+		allErrs = append(allErrs, validateIntOrPercent(*param.value, fldPath.Child(param.name))...)
+	}
+
+	// maxUnavailable defaults to 0, so a maxSurge of 0 requires an explicit
+	// maxUnavailable.
+	if !daemonSet && rollout.MaxSurge != nil && isZero(*rollout.MaxSurge) &&
+		(rollout.MaxUnavailable == nil || isZero(*rollout.MaxUnavailable)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxSurge"), rollout.MaxSurge.String(), "may not be 0 when maxUnavailable is 0"))
+	}
+
+	if rollout.ShutdownDelay != nil && rollout.ShutdownDelay.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("shutdownDelay"), rollout.ShutdownDelay.Duration.String(), "must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateHibernation validates the given [config.Hibernation].
+func validateHibernation(hibernation *config.Hibernation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch hibernation.DNSRecordPolicy {
+	case "", config.DNSRecordPolicyDelete, config.DNSRecordPolicyKeep:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("dnsRecordPolicy"), hibernation.DNSRecordPolicy, []config.DNSRecordPolicy{
+			config.DNSRecordPolicyDelete,
+			config.DNSRecordPolicyKeep,
+		}))
+	}
+
+	return allErrs
+}
+
+// validateIntOrPercent validates, that the given value is a non-negative
+// integer or a percentage between 0% and 100%.
+func validateIntOrPercent(value intstr.IntOrString, fldPath *field.Path) field.ErrorList {
+	if value.Type == intstr.Int {
+		if value.IntVal < 0 {
+			return field.ErrorList{field.Invalid(fldPath, value.IntVal, "must not be negative")}
+		}
+
+		return nil
+	}
+
+	percent, ok := strings.CutSuffix(value.StrVal, "%")
+	if n, err := strconv.Atoi(percent); !ok || err != nil || n < 0 || n > 100 {
+		return field.ErrorList{field.Invalid(fldPath, value.StrVal, "must be an integer or a percentage between 0% and 100%")}
+	}
+
+	return nil
+}
+
+// isZero returns true, if the given value is 0 or 0%.
+func isZero(value intstr.IntOrString) bool {
+	if value.Type == intstr.Int {
+		return value.IntVal == 0
+	}
+
+	return value.StrVal == "0%"
+}
+
+// validateScheduling validates the given [config.Scheduling]. The affinity is
+// validated by the API server of the shoot cluster only.
+func validateScheduling(scheduling *config.Scheduling, fldPath *field.Path) field.ErrorList {
+	allErrs := metav1validation.ValidateLabels(scheduling.NodeSelector, fldPath.Child("nodeSelector"))
+
+	tolerationsPath := fldPath.Child("tolerations")
+	for i, toleration := range scheduling.Tolerations {
+		allErrs = append(allErrs, validateToleration(toleration, tolerationsPath.Index(i))...)
+	}
+
+	if scheduling.PriorityClassName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(scheduling.PriorityClassName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("priorityClassName"), scheduling.PriorityClassName, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateToleration validates the given toleration like the API server
+// validates the tolerations of pods.
+func validateToleration(toleration corev1.Toleration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if toleration.Key != "" {
+		allErrs = append(allErrs, metav1validation.ValidateLabelName(toleration.Key, fldPath.Child("key"))...)
+	}
+
+	switch toleration.Operator {
+	case corev1.TolerationOpEqual, "":
+		if toleration.Key == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("operator"), toleration.Operator, "operator must be Exists when key is empty"))
+		}
+		for _, msg := range validation.IsValidLabelValue(toleration.Value) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("value"), toleration.Value, msg))
+		}
+	case corev1.TolerationOpExists:
+		if toleration.Value != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("value"), toleration.Value, "value must be empty when operator is Exists"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("operator"), toleration.Operator, []corev1.TolerationOperator{
+			corev1.TolerationOpEqual,
+			corev1.TolerationOpExists,
+		}))
+	}
+
+	switch toleration.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("effect"), toleration.Effect, []corev1.TaintEffect{
+			corev1.TaintEffectNoSchedule,
+			corev1.TaintEffectPreferNoSchedule,
+			corev1.TaintEffectNoExecute,
+		}))
+	}
+
+	if toleration.TolerationSeconds != nil && toleration.Effect != corev1.TaintEffectNoExecute {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("effect"), toleration.Effect, "effect must be NoExecute when tolerationSeconds is set"))
+	}
+
+	return allErrs
+}
@@ -15,9 +15,14 @@ import (
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/extension"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	predicateutils "github.com/gardener/gardener/pkg/controllerutils/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	crctrl "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // ErrInvalidController is an error, which is returned when attempting to create
@@ -101,28 +106,59 @@ func New(opts ...Option) (*Controller, error) {
 }
 
 // SetupWithManager registers the [Controller] with the given [manager.Manager].
-// Internally, this method uses [extension.Add], which builds a reconciler
-// wrapper around the [extension.Actuator] used by the [Controller].
+// The controller watches the same resources as the one of [extension.Add],
+// but reconciles them with its own reconciler, which reports a
+// [reconcilerutils.RequeueAfterError] of the [extension.Actuator] as
+// processing instead of an error.
 func (c *Controller) SetupWithManager(ctx context.Context, mgr manager.Manager) error {
 	if len(c.predicates) == 0 {
 		c.predicates = extension.DefaultPredicates(ctx, mgr, c.ignoreOperationAnnotation)
 	}
 
-	return extension.Add(
-		mgr,
-		extension.AddArgs{
-			Actuator:                  c.actuator,
-			Name:                      c.name,
-			FinalizerSuffix:           c.finalizerSuffix,
-			ControllerOptions:         c.controllerOptions,
-			Predicates:                c.predicates,
-			Resync:                    c.resync,
-			Type:                      c.extensionType,
-			WatchBuilder:              c.watchBuilder,
-			IgnoreOperationAnnotation: c.ignoreOperationAnnotation,
-			ExtensionClasses:          c.extensionClasses,
-		},
-	)
+	args := extension.AddArgs{
+		Actuator:                  c.actuator,
+		Name:                      c.name,
+		FinalizerSuffix:           c.finalizerSuffix,
+		ControllerOptions:         c.controllerOptions,
+		Predicates:                c.predicates,
+		Resync:                    c.resync,
+		Type:                      c.extensionType,
+		WatchBuilder:              c.watchBuilder,
+		IgnoreOperationAnnotation: c.ignoreOperationAnnotation,
+		ExtensionClasses:          c.extensionClasses,
+	}
+
+	predicates := []predicate.Predicate{
+		predicateutils.HasType(args.Type),
+		predicateutils.HasClass(args.ExtensionClasses...),
+	}
+	predicates = append(predicates, args.Predicates...)
+
+	ctrl, err := builder.
+		ControllerManagedBy(mgr).
+		Named(args.Name).
+		WithOptions(args.ControllerOptions).
+		Watches(
+			&extensionsv1alpha1.Extension{},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(predicates...),
+		).
+		Build(newReconciler(mgr, args))
+	if err != nil {
+		return err
+	}
+
+	if args.IgnoreOperationAnnotation {
+		if err := ctrl.Watch(source.Kind[client.Object](
+			mgr.GetCache(),
+			&extensionsv1alpha1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(extension.ClusterToExtensionMapper(mgr.GetClient(), predicates...)),
+		)); err != nil {
+			return err
+		}
+	}
+
+	return args.WatchBuilder.AddToController(ctrl)
 }
 
 // Option is a function, which configures the [Controller].
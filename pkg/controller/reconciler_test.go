@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gardener/gardener/extensions/pkg/controller/extension"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/controller"
+)
+
+// fakeActuator is an [extension.Actuator], which returns the configured
+// error from each of its operations.
+type fakeActuator struct {
+	err error
+}
+
+var _ extension.Actuator = &fakeActuator{}
+
+func (a *fakeActuator) Reconcile(context.Context, logr.Logger, *v1alpha1.Extension) error {
+	return a.err
+}
+
+func (a *fakeActuator) Delete(context.Context, logr.Logger, *v1alpha1.Extension) error {
+	return a.err
+}
+
+func (a *fakeActuator) ForceDelete(context.Context, logr.Logger, *v1alpha1.Extension) error {
+	return a.err
+}
+
+func (a *fakeActuator) Restore(context.Context, logr.Logger, *v1alpha1.Extension) error {
+	return a.err
+}
+
+func (a *fakeActuator) Migrate(context.Context, logr.Logger, *v1alpha1.Extension) error {
+	return a.err
+}
+
+var _ = Describe("Reconciler", Ordered, func() {
+	var (
+		mgrCancel context.CancelFunc
+		namespace *corev1.Namespace
+	)
+
+	// startController starts a controller for the extensions of the given
+	// type, which reconciles them with the given actuator.
+	startController := func(extensionType string, act extension.Actuator) {
+		c, err := controller.New(
+			controller.WithActuator(act),
+			controller.WithName(extensionType),
+			controller.WithExtensionType(extensionType),
+			controller.WithExtensionClass(v1alpha1.ExtensionClassShoot),
+			controller.WithPredicate(predicate.NewPredicateFuncs(func(client.Object) bool { return true })),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		m, err := manager.New(cfg, manager.Options{
+			Scheme:  scheme.Scheme,
+			Metrics: metricsserver.Options{BindAddress: "0"},
+			Logger:  logger,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.SetupWithManager(ctx, m)).To(Succeed())
+
+		var mgrCtx context.Context
+		mgrCtx, mgrCancel = context.WithCancel(ctx)
+		go func() {
+			defer GinkgoRecover()
+			Expect(m.Start(mgrCtx)).To(Succeed())
+		}()
+	}
+
+	// createExtension creates an extension of the given type and returns it.
+	createExtension := func(extensionType string) *v1alpha1.Extension {
+		ex := &v1alpha1.Extension{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      extensionType,
+				Namespace: namespace.Name,
+			},
+			Spec: v1alpha1.ExtensionSpec{
+				DefaultSpec: v1alpha1.DefaultSpec{
+					Type: extensionType,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ex)).To(Succeed())
+
+		return ex
+	}
+
+	BeforeAll(func() {
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "reconciler-",
+			},
+		}
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		if mgrCancel != nil {
+			mgrCancel()
+		}
+	})
+
+	It("should report a requeue of the actuator as processing", func() {
+		startController("requeue", &fakeActuator{
+			err: &reconcilerutils.RequeueAfterError{
+				Cause:        errors.New("waiting for the ingress address"),
+				RequeueAfter: time.Hour,
+			},
+		})
+		ex := createExtension("requeue")
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ex), ex)).To(Succeed())
+			g.Expect(ex.Status.LastOperation).NotTo(BeNil())
+			g.Expect(ex.Status.LastOperation.Type).To(Equal(gardencorev1beta1.LastOperationTypeCreate))
+			g.Expect(ex.Status.LastOperation.State).To(Equal(gardencorev1beta1.LastOperationStateProcessing))
+			g.Expect(ex.Status.LastOperation.Description).To(Equal("waiting for the ingress address, retrying in 1h0m0s"))
+			g.Expect(ex.Status.LastError).To(BeNil())
+		}).Should(Succeed())
+	})
+
+	It("should report any other error of the actuator as error", func() {
+		startController("failure", &fakeActuator{
+			err: errors.New("deployment failed"),
+		})
+		ex := createExtension("failure")
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ex), ex)).To(Succeed())
+			g.Expect(ex.Status.LastOperation).NotTo(BeNil())
+			g.Expect(ex.Status.LastOperation.State).To(Equal(gardencorev1beta1.LastOperationStateError))
+			g.Expect(ex.Status.LastError).NotTo(BeNil())
+			g.Expect(ex.Status.LastError.Description).To(ContainSubstring("deployment failed"))
+		}).Should(Succeed())
+	})
+
+	It("should report a successful reconciliation as succeeded", func() {
+		startController("success", &fakeActuator{})
+		ex := createExtension("success")
+
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ex), ex)).To(Succeed())
+			g.Expect(ex.Status.LastOperation).NotTo(BeNil())
+			g.Expect(ex.Status.LastOperation.State).To(Equal(gardencorev1beta1.LastOperationStateSucceeded))
+			g.Expect(ex.Finalizers).To(ContainElement("extensions.gardener.cloud/success"))
+		}).Should(Succeed())
+	})
+})
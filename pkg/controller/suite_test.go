@@ -6,12 +6,14 @@ package controller_test
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -38,9 +40,16 @@ var _ = BeforeSuite(func() {
 	logf.SetLogger(logger)
 
 	ctx, cancel = context.WithCancel(context.TODO())
+
+	Expect(extensionscontroller.AddToScheme(scheme.Scheme)).To(Succeed())
+
 	By("bootstrapping test environment")
 	testEnv = &envtest.Environment{
 		Scheme: scheme.Scheme,
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "test", "manifests", "crd", "extensions.gardener.cloud", "v1alpha1"),
+		},
+		ErrorIfCRDPathMissing: true,
 	}
 
 	var err error
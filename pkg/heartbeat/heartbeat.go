@@ -10,13 +10,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	heartbeatcontroller "github.com/gardener/gardener/extensions/pkg/controller/heartbeat"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/extensions"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+// The annotations of the heartbeat lease, which carry the [Info] of the
+// extension.
+const (
+	// AnnotationVersion is the annotation of the heartbeat lease, which
+	// carries the version of the extension.
+	AnnotationVersion = "traefik.extensions.gardener.cloud/version"
+	// AnnotationTraefikVersion is the annotation of the heartbeat lease,
+	// which carries the default Traefik version of the image vector.
+	AnnotationTraefikVersion = "traefik.extensions.gardener.cloud/traefik-version"
+	// AnnotationManagedShoots is the annotation of the heartbeat lease, which
+	// carries the number of shoots managed by the extension.
+	AnnotationManagedShoots = "traefik.extensions.gardener.cloud/managed-shoots"
+	// AnnotationReconcileErrors is the annotation of the heartbeat lease,
+	// which carries the numbers of shoots, whose last operation failed, by
+	// the type of the operation, e.g. "delete=1,reconcile=2".
+	AnnotationReconcileErrors = "traefik.extensions.gardener.cloud/reconcile-errors"
+)
+
 // ErrInvalidHeartbeat is an error, which is returned when attempting to create
 // a [Heartbeat], but the configuration was found to be invalid.
 var ErrInvalidHeartbeat = errors.New("invalid heartbeat config")
@@ -27,7 +56,19 @@ type Heartbeat struct {
 	extensionName string
 	namespace     string
 	renewInterval time.Duration
+	extensionType string
 	clock         clock.Clock
+	info          func() Info
+}
+
+// Info is the information about the extension, which is published in the
+// annotations of the heartbeat lease, so that operators can inspect the state
+// of the extension without access to its logs or metrics.
+type Info struct {
+	// Version is the version of the extension.
+	Version string
+	// TraefikVersion is the default Traefik version of the image vector.
+	TraefikVersion string
 }
 
 // Option is a function, which configures the [Heartbeat].
@@ -52,13 +93,20 @@ func New(opts ...Option) (*Heartbeat, error) {
 	if h.namespace == "" {
 		return nil, fmt.Errorf("%w: missing lease namespace", ErrInvalidHeartbeat)
 	}
+	if h.info != nil && h.extensionType == "" {
+		return nil, fmt.Errorf("%w: missing extension type", ErrInvalidHeartbeat)
+	}
 
 	return h, nil
 }
 
 // SetupWithManager registers the [Heartbeat] controller with the given [manager.Manager].
+//
+// If the [Heartbeat] is configured with [WithInfo], the heartbeat lease is
+// annotated with the [Info] and the state of the managed shoots on the renew
+// interval as well.
 func (h *Heartbeat) SetupWithManager(ctx context.Context, mgr manager.Manager) error {
-	return heartbeatcontroller.Add(
+	if err := heartbeatcontroller.Add(
 		mgr,
 		heartbeatcontroller.AddArgs{
 			ExtensionName:        h.extensionName,
@@ -66,7 +114,113 @@ func (h *Heartbeat) SetupWithManager(ctx context.Context, mgr manager.Manager) e
 			RenewIntervalSeconds: int32(h.renewInterval.Seconds()),
 			Clock:                h.clock,
 		},
-	)
+	); err != nil {
+		return err
+	}
+
+	if h.info == nil {
+		return nil
+	}
+
+	logger := mgr.GetLogger().WithName("heartbeat")
+	annotate := func(ctx context.Context) error {
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			if err := h.AnnotateLease(ctx, mgr.GetClient()); err != nil {
+				logger.Error(err, "failed to annotate heartbeat lease")
+			}
+		}, h.renewInterval)
+
+		return nil
+	}
+
+	return mgr.Add(manager.RunnableFunc(annotate))
+}
+
+// AnnotateLease annotates the heartbeat lease with the current [Info] of the
+// extension and the state of the managed shoots. The lease is created by the
+// heartbeat controller, so a missing lease is annotated on the next call.
+//
+// The state of the managed shoots is derived from the
+// [extensionsv1alpha1.Extension] resources of the extension type, see
+// [WithExtensionType], so that it is accurate right after a restart of the
+// extension. The given [client.Client] is expected to read them from the
+// cache of the manager.
+func (h *Heartbeat) AnnotateLease(ctx context.Context, c client.Client) error {
+	if h.info == nil {
+		return nil
+	}
+
+	managedShoots, reconcileErrors, err := h.shootState(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	lease := &coordinationv1.Lease{}
+	key := client.ObjectKey{Namespace: h.namespace, Name: extensions.HeartBeatResourceName}
+	if err := c.Get(ctx, key, lease); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get heartbeat lease: %w", err)
+	}
+
+	info := h.info()
+	patch := client.MergeFrom(lease.DeepCopy())
+	if lease.Annotations == nil {
+		lease.Annotations = make(map[string]string)
+	}
+	lease.Annotations[AnnotationVersion] = info.Version
+	lease.Annotations[AnnotationTraefikVersion] = info.TraefikVersion
+	lease.Annotations[AnnotationManagedShoots] = strconv.Itoa(managedShoots)
+	lease.Annotations[AnnotationReconcileErrors] = formatCounts(reconcileErrors)
+
+	if err := c.Patch(ctx, lease, patch); err != nil {
+		return fmt.Errorf("failed to annotate heartbeat lease: %w", err)
+	}
+
+	return nil
+}
+
+// shootState returns the number of shoots managed by the extension and the
+// numbers of shoots, whose last operation failed, by the type of the
+// operation.
+func (h *Heartbeat) shootState(ctx context.Context, c client.Client) (int, map[string]int, error) {
+	extensionList := &extensionsv1alpha1.ExtensionList{}
+	if err := c.List(ctx, extensionList); err != nil {
+		return 0, nil, fmt.Errorf("failed to list extensions: %w", err)
+	}
+
+	managedShoots := 0
+	reconcileErrors := make(map[string]int)
+	for _, ex := range extensionList.Items {
+		if ex.Spec.Type != h.extensionType {
+			continue
+		}
+		managedShoots++
+
+		lastOperation := ex.Status.LastOperation
+		if lastOperation == nil {
+			continue
+		}
+		switch lastOperation.State {
+		case gardencorev1beta1.LastOperationStateError, gardencorev1beta1.LastOperationStateFailed:
+			reconcileErrors[strings.ToLower(string(lastOperation.Type))]++
+		}
+	}
+
+	return managedShoots, reconcileErrors, nil
+}
+
+// formatCounts formats the given counts as comma-separated "key=count" pairs,
+// which are sorted by key.
+func formatCounts(counts map[string]int) string {
+	pairs := make([]string, 0, len(counts))
+	for _, key := range slices.Sorted(maps.Keys(counts)) {
+		pairs = append(pairs, key+"="+strconv.Itoa(counts[key]))
+	}
+
+	return strings.Join(pairs, ",")
 }
 
 // WithExtensionName is an [Option], which configures the [Heartbeat] to use the
@@ -93,6 +247,19 @@ func WithLeaseNamespace(namespace string) Option {
 	return opt
 }
 
+// WithExtensionType is an [Option], which configures the [Heartbeat] to derive
+// the state of the managed shoots from the [extensionsv1alpha1.Extension]
+// resources of the given type.
+func WithExtensionType(extensionType string) Option {
+	opt := func(h *Heartbeat) error {
+		h.extensionType = extensionType
+
+		return nil
+	}
+
+	return opt
+}
+
 // WithRenewInterval is an [Option], which configures the [Heartbeat] to renew
 // the lease on the given interval.
 func WithRenewInterval(interval time.Duration) Option {
@@ -116,3 +283,15 @@ func WithClock(clk clock.Clock) Option {
 
 	return opt
 }
+
+// WithInfo is an [Option], which configures the [Heartbeat] to annotate the
+// lease with the [Info] returned by the given function.
+func WithInfo(info func() Info) Option {
+	opt := func(h *Heartbeat) error {
+		h.info = info
+
+		return nil
+	}
+
+	return opt
+}
@@ -8,10 +8,19 @@ import (
 	"context"
 	"time"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/extensions"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/heartbeat"
@@ -40,6 +49,20 @@ var _ = Describe("Heartbeat Controller", Ordered, func() {
 		Expect(c).To(BeNil())
 	})
 
+	It("should fail to create heartbeat controller with info and missing extension type", func() {
+		opts := []heartbeat.Option{
+			heartbeat.WithExtensionName("example"),
+			heartbeat.WithLeaseNamespace("default"),
+			heartbeat.WithInfo(func() heartbeat.Info { return heartbeat.Info{} }),
+		}
+		c, err := heartbeat.New(opts...)
+
+		Expect(err).Should(HaveOccurred())
+		Expect(err).To(MatchError(heartbeat.ErrInvalidHeartbeat))
+		Expect(err).To(MatchError(ContainSubstring("missing extension type")))
+		Expect(c).To(BeNil())
+	})
+
 	It("should successfully create heartbeat controller and register it", func() {
 		opts := []heartbeat.Option{
 			heartbeat.WithExtensionName("example"),
@@ -58,3 +81,100 @@ var _ = Describe("Heartbeat Controller", Ordered, func() {
 		Expect(h.SetupWithManager(context.TODO(), m)).To(Succeed())
 	})
 })
+
+var _ = Describe("Heartbeat Lease Annotations", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		h      *heartbeat.Heartbeat
+	)
+
+	// newExtension returns an extension of the given type in the given
+	// namespace, whose last operation of the given type is in the given
+	// state.
+	newExtension := func(namespace, extensionType string, operationType gardencorev1beta1.LastOperationType, state gardencorev1beta1.LastOperationState) *extensionsv1alpha1.Extension {
+		ex := &extensionsv1alpha1.Extension{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      extensionType,
+			},
+			Spec: extensionsv1alpha1.ExtensionSpec{
+				DefaultSpec: extensionsv1alpha1.DefaultSpec{
+					Type: extensionType,
+				},
+			},
+		}
+		if state != "" {
+			ex.Status.LastOperation = &gardencorev1beta1.LastOperation{
+				Type:  operationType,
+				State: state,
+			}
+		}
+
+		return ex
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(extensionsv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		var err error
+		h, err = heartbeat.New(
+			heartbeat.WithExtensionName("example"),
+			heartbeat.WithLeaseNamespace("default"),
+			heartbeat.WithExtensionType("shoot-traefik"),
+			heartbeat.WithInfo(func() heartbeat.Info {
+				return heartbeat.Info{
+					Version:        "v1.2.3",
+					TraefikVersion: "v3.6.2",
+				}
+			}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should ignore a missing lease", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		Expect(h.AnnotateLease(ctx, c)).To(Succeed())
+	})
+
+	It("should annotate the lease with the info of the extension", func() {
+		lease := &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        extensions.HeartBeatResourceName,
+				Annotations: map[string]string{"foo": "bar"},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			lease,
+			newExtension("shoot--foo--a", "shoot-traefik", "", ""),
+			newExtension("shoot--foo--b", "shoot-traefik", gardencorev1beta1.LastOperationTypeReconcile, gardencorev1beta1.LastOperationStateSucceeded),
+			newExtension("shoot--foo--c", "shoot-traefik", gardencorev1beta1.LastOperationTypeReconcile, gardencorev1beta1.LastOperationStateProcessing),
+			newExtension("shoot--foo--d", "shoot-traefik", gardencorev1beta1.LastOperationTypeReconcile, gardencorev1beta1.LastOperationStateError),
+			newExtension("shoot--foo--e", "shoot-traefik", gardencorev1beta1.LastOperationTypeCreate, gardencorev1beta1.LastOperationStateError),
+			newExtension("shoot--foo--f", "shoot-traefik", gardencorev1beta1.LastOperationTypeDelete, gardencorev1beta1.LastOperationStateFailed),
+			newExtension("shoot--foo--f", "shoot-other", gardencorev1beta1.LastOperationTypeReconcile, gardencorev1beta1.LastOperationStateError),
+		).Build()
+
+		Expect(h.AnnotateLease(ctx, c)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(lease), lease)).To(Succeed())
+		Expect(lease.Annotations).To(Equal(map[string]string{
+			"foo":                               "bar",
+			heartbeat.AnnotationVersion:         "v1.2.3",
+			heartbeat.AnnotationTraefikVersion:  "v3.6.2",
+			heartbeat.AnnotationManagedShoots:   "6",
+			heartbeat.AnnotationReconcileErrors: "create=1,delete=1,reconcile=1",
+		}))
+
+		Expect(c.DeleteAllOf(ctx, &extensionsv1alpha1.Extension{}, client.InNamespace("shoot--foo--d"))).To(Succeed())
+		Expect(c.DeleteAllOf(ctx, &extensionsv1alpha1.Extension{}, client.InNamespace("shoot--foo--e"))).To(Succeed())
+		Expect(c.DeleteAllOf(ctx, &extensionsv1alpha1.Extension{}, client.InNamespace("shoot--foo--f"))).To(Succeed())
+		Expect(h.AnnotateLease(ctx, c)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(lease), lease)).To(Succeed())
+		Expect(lease.Annotations).To(HaveKeyWithValue(heartbeat.AnnotationManagedShoots, "3"))
+		Expect(lease.Annotations).To(HaveKeyWithValue(heartbeat.AnnotationReconcileErrors, ""))
+	})
+})
@@ -3,9 +3,17 @@
 // SPDX-License-Identifier: Apache-2.0
 
 // Package metrics specifies various metrics provided by the extension.
+//
+// The metrics are not labeled with the shoot, so that their cardinality stays
+// bounded on seeds with thousands of shoots.
 package metrics
 
 import (
+	"errors"
+	"sync"
+	"time"
+
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	"github.com/prometheus/client_golang/prometheus"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -13,25 +21,78 @@ import (
 // Namespace is the namespace component of the fully qualified metric name.
 const Namespace = "gardener_extension_traefik"
 
+// Results of actuator operations.
+const (
+	// ResultSuccess is the result of a successful actuator operation.
+	ResultSuccess = "success"
+	// ResultError is the result of a failed actuator operation.
+	ResultError = "error"
+	// ResultRequeue is the result of an actuator operation, which waits for
+	// something and is requeued, see [reconcilerutils.RequeueAfterError].
+	ResultRequeue = "requeue"
+)
+
+// Reasons of actuator errors.
+const (
+	// ErrorReasonDecode is the reason of errors, which are caused by a provider
+	// config, which cannot be decoded or is invalid.
+	ErrorReasonDecode = "decode"
+	// ErrorReasonDeploy is the reason of errors, which occur while deploying
+	// Traefik or waiting for its rollout.
+	ErrorReasonDeploy = "deploy"
+	// ErrorReasonDNS is the reason of errors, which occur while deploying or
+	// deleting the ingress DNS record.
+	ErrorReasonDNS = "dns"
+	// ErrorReasonLBPending is the reason of errors, which are returned while
+	// Traefik has no address for the ingress DNS record yet.
+	ErrorReasonLBPending = "lb_pending"
+)
+
 var (
-	// ActuatorOperationTotal counts each invocation of the traefik extension actuator.
+	// ActuatorOperationTotal counts each invocation of the traefik extension
+	// actuator by its result.
 	ActuatorOperationTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "actuator_operation_total",
 			Help:      "Total number of actuator operations for the traefik extension",
 		},
-		[]string{"cluster", "operation"},
+		[]string{"operation", "result"},
 	)
 
-	// ActuatorOperationDurationSeconds tracks the duration of each actuator operation.
-	ActuatorOperationDurationSeconds = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
+	// ActuatorOperationDurationSeconds tracks the duration of each actuator
+	// operation. The buckets cover operations, which wait for the rollout of
+	// Traefik or the deletion of a ManagedResource for several minutes.
+	ActuatorOperationDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
 			Namespace: Namespace,
 			Name:      "actuator_operation_duration_seconds",
 			Help:      "Duration in seconds of actuator operations for the traefik extension",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+		},
+		[]string{"operation"},
+	)
+
+	// ActuatorErrorsTotal counts the errors of the traefik extension actuator
+	// by their reason.
+	ActuatorErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "actuator_errors_total",
+			Help:      "Total number of actuator errors for the traefik extension",
 		},
-		[]string{"cluster", "operation"},
+		[]string{"reason"},
+	)
+
+	// ManagedShoots tracks the number of shoots, which are managed by the
+	// traefik extension, by their ingress provider and Traefik version.
+	ManagedShoots = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "managed_shoots",
+			Help:      "Number of shoots managed by the traefik extension",
+		},
+		[]string{"ingress_provider", "version"},
 	)
 )
 
@@ -40,5 +101,87 @@ func init() {
 	ctrlmetrics.Registry.MustRegister(
 		ActuatorOperationTotal,
 		ActuatorOperationDurationSeconds,
+		ActuatorErrorsTotal,
+		ManagedShoots,
+		WebhookShootAdmissionTotal,
+		WebhookShootAdmissionDurationSeconds,
 	)
 }
+
+// shoot holds the labels of a shoot in the [ManagedShoots] metric.
+type shoot struct {
+	ingressProvider string
+	version         string
+}
+
+var (
+	shootsMutex sync.Mutex
+	// shoots are the labels of the managed shoots by their control plane
+	// namespace.
+	shoots = make(map[string]shoot)
+	// shootCounts are the numbers of managed shoots by their labels.
+	shootCounts = make(map[shoot]int)
+)
+
+// SetManagedShoot records the ingress provider and Traefik version of the
+// shoot with the given control plane namespace in the [ManagedShoots] metric.
+func SetManagedShoot(namespace, ingressProvider, version string) {
+	shootsMutex.Lock()
+	defer shootsMutex.Unlock()
+
+	labels := shoot{ingressProvider: ingressProvider, version: version}
+	if current, ok := shoots[namespace]; ok {
+		if current == labels {
+			return
+		}
+		removeShoot(current)
+	}
+
+	shoots[namespace] = labels
+	shootCounts[labels]++
+	ManagedShoots.WithLabelValues(ingressProvider, version).Set(float64(shootCounts[labels]))
+}
+
+// DeleteManagedShoot removes the shoot with the given control plane namespace
+// from the [ManagedShoots] metric.
+func DeleteManagedShoot(namespace string) {
+	shootsMutex.Lock()
+	defer shootsMutex.Unlock()
+
+	if current, ok := shoots[namespace]; ok {
+		delete(shoots, namespace)
+		removeShoot(current)
+	}
+}
+
+// removeShoot decrements the number of managed shoots with the given labels.
+// The series of the labels is removed, once no shoot has them anymore, e.g.
+// after all shoots are updated to a new Traefik version.
+func removeShoot(labels shoot) {
+	shootCounts[labels]--
+	if shootCounts[labels] > 0 {
+		ManagedShoots.WithLabelValues(labels.ingressProvider, labels.version).Set(float64(shootCounts[labels]))
+
+		return
+	}
+
+	delete(shootCounts, labels)
+	ManagedShoots.DeleteLabelValues(labels.ingressProvider, labels.version)
+}
+
+// ObserveOperation records the result and the duration of the actuator
+// operation with the given name, which started at the given time and
+// returned the given error.
+func ObserveOperation(operation string, start time.Time, err error) {
+	var requeueErr *reconcilerutils.RequeueAfterError
+	result := ResultSuccess
+	switch {
+	case errors.As(err, &requeueErr):
+		result = ResultRequeue
+	case err != nil:
+		result = ResultError
+	}
+
+	ActuatorOperationTotal.WithLabelValues(operation, result).Inc()
+	ActuatorOperationDurationSeconds.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestManagedShoots(t *testing.T) {
+	SetManagedShoot("shoot--foo--a", "KubernetesIngress", "v3.6.2")
+	SetManagedShoot("shoot--foo--b", "KubernetesIngress", "v3.6.2")
+	SetManagedShoot("shoot--foo--c", "KubernetesIngressNGINX", "v3.6.2")
+	// Reconciling a shoot again does not change the metric.
+	SetManagedShoot("shoot--foo--a", "KubernetesIngress", "v3.6.2")
+
+	if count := testutil.ToFloat64(ManagedShoots.WithLabelValues("KubernetesIngress", "v3.6.2")); count != 2 {
+		t.Errorf("expected 2 shoots with KubernetesIngress, got: %v", count)
+	}
+	if count := testutil.ToFloat64(ManagedShoots.WithLabelValues("KubernetesIngressNGINX", "v3.6.2")); count != 1 {
+		t.Errorf("expected 1 shoot with KubernetesIngressNGINX, got: %v", count)
+	}
+
+	// Updating the version of a shoot moves it to the new version.
+	SetManagedShoot("shoot--foo--c", "KubernetesIngressNGINX", "v3.7.0")
+	DeleteManagedShoot("shoot--foo--b")
+	DeleteManagedShoot("shoot--foo--unknown")
+
+	if series := testutil.CollectAndCount(ManagedShoots); series != 2 {
+		t.Errorf("expected 2 series, got: %d", series)
+	}
+	if count := testutil.ToFloat64(ManagedShoots.WithLabelValues("KubernetesIngress", "v3.6.2")); count != 1 {
+		t.Errorf("expected 1 shoot with KubernetesIngress, got: %v", count)
+	}
+	if count := testutil.ToFloat64(ManagedShoots.WithLabelValues("KubernetesIngressNGINX", "v3.7.0")); count != 1 {
+		t.Errorf("expected 1 shoot with KubernetesIngressNGINX v3.7.0, got: %v", count)
+	}
+
+	DeleteManagedShoot("shoot--foo--a")
+	DeleteManagedShoot("shoot--foo--c")
+	if series := testutil.CollectAndCount(ManagedShoots); series != 0 {
+		t.Errorf("expected no series, got: %d", series)
+	}
+}
+
+func TestObserveOperation(t *testing.T) {
+	start := time.Now()
+	ObserveOperation("reconcile", start, nil)
+	ObserveOperation("reconcile", start, errors.New("failed"))
+	ObserveOperation("reconcile", start, errors.New("failed"))
+	ObserveOperation("reconcile", start, &reconcilerutils.RequeueAfterError{Cause: errors.New("pending"), RequeueAfter: time.Second})
+
+	if count := testutil.ToFloat64(ActuatorOperationTotal.WithLabelValues("reconcile", ResultSuccess)); count != 1 {
+		t.Errorf("expected 1 successful operation, got: %v", count)
+	}
+	if count := testutil.ToFloat64(ActuatorOperationTotal.WithLabelValues("reconcile", ResultError)); count != 2 {
+		t.Errorf("expected 2 failed operations, got: %v", count)
+	}
+	if count := testutil.ToFloat64(ActuatorOperationTotal.WithLabelValues("reconcile", ResultRequeue)); count != 1 {
+		t.Errorf("expected 1 requeued operation, got: %v", count)
+	}
+	if series := testutil.CollectAndCount(ActuatorOperationDurationSeconds); series != 1 {
+		t.Errorf("expected 1 histogram, got: %d", series)
+	}
+}
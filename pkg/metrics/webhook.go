@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Decisions of the admission webhook.
+const (
+	// DecisionAllowed is the decision of the admission webhook for an
+	// allowed Shoot.
+	DecisionAllowed = "allowed"
+	// DecisionDenied is the decision of the admission webhook for a denied
+	// Shoot.
+	DecisionDenied = "denied"
+)
+
+// Reasons of the decisions of the admission webhook.
+const (
+	// AdmissionReasonNone is the reason of allowed Shoots.
+	AdmissionReasonNone = "none"
+	// AdmissionReasonPurpose is the reason of Shoots, which are denied,
+	// because they do not have the purpose "evaluation".
+	AdmissionReasonPurpose = "purpose"
+	// AdmissionReasonInvalidConfig is the reason of Shoots, which are denied
+	// because of an invalid provider config, e.g. an unsupported Traefik
+	// version.
+	AdmissionReasonInvalidConfig = "invalid_config"
+)
+
+var (
+	// WebhookShootAdmissionTotal counts the Shoots, which are validated by
+	// the admission webhook, by its decision and the reason of the decision.
+	WebhookShootAdmissionTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "webhook_shoot_admission_total",
+			Help:      "Total number of shoots validated by the traefik admission webhook",
+		},
+		[]string{"decision", "reason"},
+	)
+
+	// WebhookShootAdmissionDurationSeconds tracks the duration of the
+	// validation of Shoots by the admission webhook.
+	WebhookShootAdmissionDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "webhook_shoot_admission_duration_seconds",
+			Help:      "Duration in seconds of the validation of shoots by the traefik admission webhook",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 12),
+		},
+		[]string{"decision"},
+	)
+)
+
+// ObserveShootAdmission records the decision of the admission webhook for a
+// Shoot with the given reason and the duration of its validation, which
+// started at the given time.
+func ObserveShootAdmission(decision, reason string, start time.Time) {
+	WebhookShootAdmissionTotal.WithLabelValues(decision, reason).Inc()
+	WebhookShootAdmissionDurationSeconds.WithLabelValues(decision).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package operatorconfig provides utilities for loading and hot-reloading the
+// operator configuration of the extension.
+package operatorconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/traefik"
+)
+
+// ErrInvalidConfig is an error, which is returned when the operator
+// configuration was found to be invalid.
+var ErrInvalidConfig = errors.New("invalid operator configuration")
+
+// knownIngressProviders contains the Ingress providers supported by the
+// extension.
+var knownIngressProviders = map[config.IngressProviderType]struct{}{
+	config.IngressProviderKubernetesIngress:      {},
+	config.IngressProviderKubernetesIngressNGINX: {},
+}
+
+// Load reads the [config.TraefikOperatorConfiguration] from the file at the
+// given path and validates it.
+func Load(path string, decoder runtime.Decoder) (*config.TraefikOperatorConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator configuration: %w", err)
+	}
+
+	cfg := &config.TraefikOperatorConfiguration{}
+	if err := runtime.DecodeInto(decoder, data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode operator configuration: %w", err)
+	}
+
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate validates the given [config.TraefikOperatorConfiguration]. Besides
+// validating the individual settings, it verifies that the defaults do not
+// exceed the limits of the configuration.
+func Validate(cfg *config.TraefikOperatorConfiguration) error {
+	if cfg.Defaults.Replicas != nil && *cfg.Defaults.Replicas < 1 {
+		return fmt.Errorf("%w: default replicas must be at least 1", ErrInvalidConfig)
+	}
+	if cfg.Limits.MaxReplicas != nil && *cfg.Limits.MaxReplicas < 1 {
+		return fmt.Errorf("%w: max replicas must be at least 1", ErrInvalidConfig)
+	}
+
+	if cfg.Defaults.IngressProvider != "" {
+		if _, ok := knownIngressProviders[cfg.Defaults.IngressProvider]; !ok {
+			return fmt.Errorf("%w: unknown default ingress provider %q", ErrInvalidConfig, cfg.Defaults.IngressProvider)
+		}
+	}
+	for _, provider := range cfg.Limits.AllowedIngressProviders {
+		if _, ok := knownIngressProviders[provider]; !ok {
+			return fmt.Errorf("%w: unknown allowed ingress provider %q", ErrInvalidConfig, provider)
+		}
+	}
+
+	for _, prefix := range cfg.Limits.AllowedArgumentPrefixes {
+		if !strings.HasPrefix(prefix, "--") {
+			return fmt.Errorf("%w: allowed argument prefix %q must start with --", ErrInvalidConfig, prefix)
+		}
+	}
+
+	// The defaults are applied to shoots without a provider config, so they
+	// must be valid on their own.
+	if _, err := traefik.NewConfig(cfg, nil); err != nil {
+		return fmt.Errorf("%w: defaults are not valid: %w", ErrInvalidConfig, err)
+	}
+
+	return nil
+}
+
+// Store holds the current [config.TraefikOperatorConfiguration]. It is safe
+// for concurrent use.
+type Store struct {
+	mu  sync.RWMutex
+	cfg *config.TraefikOperatorConfiguration
+}
+
+// NewStore creates a new [Store] holding the given configuration.
+func NewStore(cfg *config.TraefikOperatorConfiguration) *Store {
+	return &Store{cfg: cfg}
+}
+
+// Get returns a copy of the current configuration. It returns nil, if the
+// [Store] is nil or holds no configuration.
+func (s *Store) Get() *config.TraefikOperatorConfiguration {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cfg.DeepCopy()
+}
+
+// Set replaces the current configuration with the given one.
+func (s *Store) Set(cfg *config.TraefikOperatorConfiguration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cfg = cfg
+}
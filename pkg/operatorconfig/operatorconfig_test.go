@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatorconfig_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	configinstall "github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config/install"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/operatorconfig"
+)
+
+const validConfig = `apiVersion: traefik.extensions.gardener.cloud/v1alpha1
+kind: TraefikOperatorConfiguration
+defaults:
+  replicas: 3
+  logLevel: Warn
+limits:
+  maxReplicas: 5
+  allowedIngressProviders:
+  - KubernetesIngress
+features:
+  dashboard: false
+`
+
+// replaceFile atomically replaces the file at the given path with the given
+// data, like the kubelet updates ConfigMap volumes, so that the watcher never
+// reads a partially written file.
+func replaceFile(path, data string) {
+	tmp := path + ".tmp"
+	Expect(os.WriteFile(tmp, []byte(data), 0o600)).To(Succeed())
+	Expect(os.Rename(tmp, path)).To(Succeed())
+}
+
+var _ = Describe("Operator Config", func() {
+	var (
+		decoder runtime.Decoder
+		path    string
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		configinstall.Install(scheme)
+		decoder = serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder()
+		path = filepath.Join(GinkgoT().TempDir(), "config.yaml")
+	})
+
+	It("should load a valid configuration", func() {
+		Expect(os.WriteFile(path, []byte(validConfig), 0o600)).To(Succeed())
+
+		cfg, err := operatorconfig.Load(path, decoder)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Defaults.Replicas).To(HaveValue(BeEquivalentTo(3)))
+		Expect(cfg.Defaults.LogLevel).To(Equal("Warn"))
+		Expect(cfg.Limits.MaxReplicas).To(HaveValue(BeEquivalentTo(5)))
+		Expect(cfg.Features.Dashboard).To(HaveValue(BeFalse()))
+	})
+
+	It("should fail to load a configuration with unknown fields", func() {
+		Expect(os.WriteFile(path, []byte(validConfig+"unknown: true\n"), 0o600)).To(Succeed())
+
+		_, err := operatorconfig.Load(path, decoder)
+		Expect(err).To(MatchError(ContainSubstring("failed to decode operator configuration")))
+	})
+
+	It("should fail to load a configuration with defaults exceeding the limits", func() {
+		data := `apiVersion: traefik.extensions.gardener.cloud/v1alpha1
+kind: TraefikOperatorConfiguration
+defaults:
+  ingressProvider: KubernetesIngressNGINX
+limits:
+  allowedIngressProviders:
+  - KubernetesIngress
+`
+		Expect(os.WriteFile(path, []byte(data), 0o600)).To(Succeed())
+
+		_, err := operatorconfig.Load(path, decoder)
+		Expect(err).To(MatchError(operatorconfig.ErrInvalidConfig))
+	})
+
+	It("should fail to load a configuration with an unknown ingress provider", func() {
+		data := `apiVersion: traefik.extensions.gardener.cloud/v1alpha1
+kind: TraefikOperatorConfiguration
+limits:
+  allowedIngressProviders:
+  - Gateway
+`
+		Expect(os.WriteFile(path, []byte(data), 0o600)).To(Succeed())
+
+		_, err := operatorconfig.Load(path, decoder)
+		Expect(err).To(MatchError(ContainSubstring("unknown allowed ingress provider")))
+	})
+
+	It("should fail to load a configuration with an invalid argument prefix", func() {
+		data := `apiVersion: traefik.extensions.gardener.cloud/v1alpha1
+kind: TraefikOperatorConfiguration
+limits:
+  allowedArgumentPrefixes:
+  - accesslog
+`
+		Expect(os.WriteFile(path, []byte(data), 0o600)).To(Succeed())
+
+		_, err := operatorconfig.Load(path, decoder)
+		Expect(err).To(MatchError(ContainSubstring("must start with --")))
+	})
+
+	It("should return nil from a nil store", func() {
+		var store *operatorconfig.Store
+		Expect(store.Get()).To(BeNil())
+	})
+
+	It("should reload the configuration on changes and keep it on errors", func() {
+		Expect(os.WriteFile(path, []byte(validConfig), 0o600)).To(Succeed())
+		cfg, err := operatorconfig.Load(path, decoder)
+		Expect(err).NotTo(HaveOccurred())
+
+		store := operatorconfig.NewStore(cfg)
+		watcher := operatorconfig.NewWatcher(path, decoder, store, logr.Discard())
+		Expect(watcher.NeedLeaderElection()).To(BeFalse())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		done := make(chan error)
+		go func() { done <- watcher.Start(ctx) }()
+
+		// Give the watcher some time to set up the watch.
+		time.Sleep(100 * time.Millisecond)
+
+		updated := `apiVersion: traefik.extensions.gardener.cloud/v1alpha1
+kind: TraefikOperatorConfiguration
+defaults:
+  replicas: 4
+`
+		replaceFile(path, updated)
+		Eventually(func() any {
+			return store.Get().Defaults.Replicas
+		}).Should(HaveValue(BeEquivalentTo(4)))
+
+		replaceFile(path, "invalid")
+		Consistently(func() any {
+			return store.Get().Defaults.Replicas
+		}, 300*time.Millisecond).Should(HaveValue(BeEquivalentTo(4)))
+
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	})
+})
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatorconfig
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Watcher reloads the operator configuration into a [Store], whenever the
+// configuration file changes. Invalid configurations are rejected and the
+// previous configuration is kept.
+//
+// The directory of the configuration file is watched instead of the file
+// itself, because ConfigMap volumes update their files by atomically swapping
+// symlinks.
+type Watcher struct {
+	path    string
+	decoder runtime.Decoder
+	store   *Store
+	logger  logr.Logger
+}
+
+var (
+	_ manager.Runnable               = &Watcher{}
+	_ manager.LeaderElectionRunnable = &Watcher{}
+)
+
+// NewWatcher creates a new [Watcher], which reloads the configuration file at
+// the given path into the given [Store].
+func NewWatcher(path string, decoder runtime.Decoder, store *Store, logger logr.Logger) *Watcher {
+	return &Watcher{
+		path:    filepath.Clean(path),
+		decoder: decoder,
+		store:   store,
+		logger:  logger.WithName("operator-config-watcher"),
+	}
+}
+
+// Start watches the configuration file until the given context is cancelled.
+// This method implements the [manager.Runnable] interface.
+func (w *Watcher) Start(ctx context.Context) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer func() { _ = fsWatcher.Close() }()
+
+	if err := fsWatcher.Add(filepath.Dir(w.path)); err != nil {
+		return fmt.Errorf("failed to watch operator configuration: %w", err)
+	}
+
+	w.logger.Info("watching operator configuration", "path", w.path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return nil
+			}
+			if !w.isRelevant(event) {
+				continue
+			}
+			if err := w.Reload(); err != nil {
+				w.logger.Error(err, "failed to reload operator configuration, keeping previous configuration")
+			}
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.logger.Error(err, "error while watching operator configuration")
+		}
+	}
+}
+
+// NeedLeaderElection implements the [manager.LeaderElectionRunnable]
+// interface. The configuration is reloaded on all replicas, so that it is
+// up-to-date when a replica becomes the leader.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Reload loads the configuration file and stores it in the [Store], if it is
+// valid.
+func (w *Watcher) Reload() error {
+	cfg, err := Load(w.path, w.decoder)
+	if err != nil {
+		return err
+	}
+
+	w.store.Set(cfg)
+	w.logger.Info("reloaded operator configuration", "path", w.path)
+
+	return nil
+}
+
+// isRelevant returns true, if the given event may have changed the contents of
+// the configuration file.
+func (w *Watcher) isRelevant(event fsnotify.Event) bool {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+		return false
+	}
+
+	// ConfigMap volumes swap the "..data" symlink on updates.
+	name := filepath.Clean(event.Name)
+
+	return name == w.path || filepath.Base(name) == "..data"
+}
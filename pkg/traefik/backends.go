@@ -0,0 +1,321 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ingressClassAnnotation is the deprecated annotation for selecting the
+// ingress class of an Ingress, which is still honored by Traefik.
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
+// ingressRouteListGVK is the GroupVersionKind of the IngressRoute list.
+var ingressRouteListGVK = schema.GroupVersionKind{
+	Group:   "traefik.io",
+	Version: "v1alpha1",
+	Kind:    "IngressRouteList",
+}
+
+// Backend is a set of pods, which Traefik may reach in the Restricted
+// NetworkPolicy mode.
+type Backend struct {
+	// Namespace is the namespace of the pods.
+	Namespace string
+	// PodSelector selects the pods of the backend.
+	PodSelector map[string]string
+	// Ports are the target ports of the pods. All ports are allowed, if
+	// empty.
+	Ports []intstr.IntOrString
+}
+
+// serviceRef is a reference to a port of a backend service.
+type serviceRef struct {
+	service types.NamespacedName
+	port    intstr.IntOrString
+}
+
+// DiscoverBackends returns the backends of the Ingress and IngressRoute
+// resources in the shoot cluster, which are served by Traefik with the given
+// config. The backends are derived from the pods selected by the referenced
+// services. Services without a selector are skipped, because their endpoints
+// cannot be selected by a NetworkPolicy.
+func DiscoverBackends(ctx context.Context, c client.Reader, cfg Config) ([]Backend, error) {
+	ingressRefs, err := ingressServiceRefs(ctx, c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	routeRefs, err := ingressRouteServiceRefs(ctx, c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*Backend)
+	allPorts := make(map[string]bool)
+	for _, ref := range append(ingressRefs, routeRefs...) {
+		svc := &corev1.Service{}
+		if err := c.Get(ctx, ref.service, svc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to get backend service %s: %w", ref.service, err)
+		}
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+
+		key := svc.Namespace + "/" + labels.SelectorFromSet(svc.Spec.Selector).String()
+		backend, ok := byKey[key]
+		if !ok {
+			backend = &Backend{
+				Namespace:   svc.Namespace,
+				PodSelector: maps.Clone(svc.Spec.Selector),
+			}
+			byKey[key] = backend
+		}
+
+		// All ports of the backend are allowed, if a port cannot be
+		// resolved, rather than breaking the route.
+		targetPort, ok := serviceTargetPort(svc, ref.port)
+		if !ok {
+			allPorts[key] = true
+
+			continue
+		}
+		if !slices.Contains(backend.Ports, targetPort) {
+			backend.Ports = append(backend.Ports, targetPort)
+		}
+	}
+
+	backends := make([]Backend, 0, len(byKey))
+	for _, key := range slices.Sorted(maps.Keys(byKey)) {
+		backend := *byKey[key]
+		if allPorts[key] {
+			backend.Ports = nil
+		}
+		slices.SortFunc(backend.Ports, func(a, b intstr.IntOrString) int {
+			return cmp.Compare(a.String(), b.String())
+		})
+		backends = append(backends, backend)
+	}
+
+	return backends, nil
+}
+
+// ingressServiceRefs returns the service references of the Ingress resources,
+// which are served by Traefik with the given config.
+func ingressServiceRefs(ctx context.Context, c client.Reader, cfg Config) ([]serviceRef, error) {
+	ingresses, err := listIngresses(ctx, c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := []serviceRef{}
+	for _, ing := range ingresses {
+		backends := []*networkingv1.IngressBackend{ing.Spec.DefaultBackend}
+		for _, rule := range ing.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				backends = append(backends, &path.Backend)
+			}
+		}
+
+		for _, backend := range backends {
+			if backend == nil || backend.Service == nil {
+				continue
+			}
+			port := intstr.FromInt32(backend.Service.Port.Number)
+			if backend.Service.Port.Name != "" {
+				port = intstr.FromString(backend.Service.Port.Name)
+			}
+			refs = append(refs, serviceRef{
+				service: types.NamespacedName{Namespace: ing.Namespace, Name: backend.Service.Name},
+				port:    port,
+			})
+		}
+	}
+
+	return refs, nil
+}
+
+// listIngresses returns the Ingress resources in the watched namespaces, which
+// are served by Traefik with the given config.
+func listIngresses(ctx context.Context, c client.Reader, cfg Config) ([]networkingv1.Ingress, error) {
+	opts := []client.ListOption{}
+	if cfg.LabelSelector != "" {
+		selector, err := labels.Parse(cfg.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse label selector: %w", err)
+		}
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	ingresses, err := listInNamespaces(ctx, cfg.Namespaces, func(ctx context.Context, opts ...client.ListOption) ([]networkingv1.Ingress, error) {
+		list := &networkingv1.IngressList{}
+		if err := c.List(ctx, list, opts...); err != nil {
+			return nil, err
+		}
+
+		return list.Items, nil
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+
+	ingressClass := cfg.IngressClassName()
+
+	return slices.DeleteFunc(ingresses, func(ing networkingv1.Ingress) bool {
+		class := ing.Annotations[ingressClassAnnotation]
+		if ing.Spec.IngressClassName != nil {
+			class = *ing.Spec.IngressClassName
+		}
+
+		return class != ingressClass
+	}), nil
+}
+
+// ingressRouteServiceRefs returns the service references of the IngressRoute
+// resources in the watched namespaces. IngressRoutes are skipped, if their
+// CRD is not installed yet.
+func ingressRouteServiceRefs(ctx context.Context, c client.Reader, cfg Config) ([]serviceRef, error) {
+	routes, err := listInNamespaces(ctx, cfg.Namespaces, func(ctx context.Context, opts ...client.ListOption) ([]unstructured.Unstructured, error) {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(ingressRouteListGVK)
+		if err := c.List(ctx, list, opts...); err != nil {
+			return nil, err
+		}
+
+		return list.Items, nil
+	})
+	if err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to list ingress routes: %w", err)
+	}
+
+	refs := []serviceRef{}
+	for _, route := range routes {
+		specRoutes, _, _ := unstructured.NestedSlice(route.Object, "spec", "routes")
+		for _, r := range specRoutes {
+			routeMap, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+			services, _, _ := unstructured.NestedSlice(routeMap, "services")
+			for _, s := range services {
+				svc, ok := s.(map[string]any)
+				if !ok {
+					continue
+				}
+				if ref, ok := ingressRouteServiceRef(route.GetNamespace(), svc); ok {
+					refs = append(refs, ref)
+				}
+			}
+		}
+	}
+
+	return refs, nil
+}
+
+// ingressRouteServiceRef returns the service reference of the given service
+// of an IngressRoute. TraefikServices are skipped, because they reference
+// other services themselves.
+func ingressRouteServiceRef(namespace string, svc map[string]any) (serviceRef, bool) {
+	kind, _, _ := unstructured.NestedString(svc, "kind")
+	if kind != "" && kind != "Service" {
+		return serviceRef{}, false
+	}
+
+	name, _, _ := unstructured.NestedString(svc, "name")
+	if name == "" {
+		return serviceRef{}, false
+	}
+	if ns, _, _ := unstructured.NestedString(svc, "namespace"); ns != "" {
+		namespace = ns
+	}
+
+	var port intstr.IntOrString
+	switch p := svc["port"].(type) {
+	case int64:
+		port = intstr.FromInt32(int32(p))
+	case float64:
+		port = intstr.FromInt32(int32(p))
+	case string:
+		port = intstr.Parse(p)
+	}
+
+	return serviceRef{
+		service: types.NamespacedName{Namespace: namespace, Name: name},
+		port:    port,
+	}, true
+}
+
+// serviceTargetPort resolves the target port of the given port of the
+// service, which is either the port number or the name of the port.
+func serviceTargetPort(svc *corev1.Service, port intstr.IntOrString) (intstr.IntOrString, bool) {
+	for _, sp := range svc.Spec.Ports {
+		matches := (port.Type == intstr.Int && port.IntVal == sp.Port) ||
+			(port.Type == intstr.String && port.StrVal != "" && strings.EqualFold(port.StrVal, sp.Name))
+		if !matches {
+			continue
+		}
+
+		if sp.TargetPort.Type == intstr.String && sp.TargetPort.StrVal != "" {
+			return sp.TargetPort, true
+		}
+		if sp.TargetPort.Type == intstr.Int && sp.TargetPort.IntVal != 0 {
+			return sp.TargetPort, true
+		}
+
+		return intstr.FromInt32(sp.Port), true
+	}
+
+	return intstr.IntOrString{}, false
+}
+
+// listInNamespaces lists objects via the given list function in the given
+// namespaces, or in all namespaces if none are given.
+func listInNamespaces[T any](
+	ctx context.Context,
+	namespaces []string,
+	list func(ctx context.Context, opts ...client.ListOption) ([]T, error),
+	opts ...client.ListOption,
+) ([]T, error) {
+	if len(namespaces) == 0 {
+		return list(ctx, opts...)
+	}
+
+	items := []T{}
+	for _, namespace := range namespaces {
+		nsItems, err := list(ctx, append(slices.Clone(opts), client.InNamespace(namespace))...)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, nsItems...)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+func backendService(namespace, name string, selector map[string]string, ports ...corev1.ServicePort) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+}
+
+func backendIngress(namespace, name, class, service string, port networkingv1.ServiceBackendPort) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{"team": namespace}},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: new(class),
+			Rules: []networkingv1.IngressRule{
+				{
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: new(networkingv1.PathTypePrefix),
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{Name: service, Port: port},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func backendIngressRoute(namespace, name string, services ...map[string]any) *unstructured.Unstructured {
+	svcs := make([]any, 0, len(services))
+	for _, svc := range services {
+		svcs = append(svcs, svc)
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"routes": []any{
+				map[string]any{
+					"match":    "Host(`example.com`)",
+					"services": svcs,
+				},
+			},
+		},
+	}}
+	route.SetAPIVersion("traefik.io/v1alpha1")
+	route.SetKind("IngressRoute")
+	route.SetNamespace(namespace)
+	route.SetName(name)
+
+	return route
+}
+
+func TestDiscoverBackends(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to create scheme: %v", err)
+	}
+	scheme.AddKnownTypeWithName(ingressRouteListGVK.GroupVersion().WithKind("IngressRoute"), &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(ingressRouteListGVK, &unstructured.UnstructuredList{})
+
+	objects := []client.Object{
+		backendService("app-a", "web", map[string]string{"app": "web"},
+			corev1.ServicePort{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+			corev1.ServicePort{Name: "admin", Port: 9090},
+		),
+		backendService("app-b", "api", map[string]string{"app": "api"},
+			corev1.ServicePort{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080)},
+		),
+		backendService("app-b", "external", nil),
+		backendIngress("app-a", "web", "traefik", "web", networkingv1.ServiceBackendPort{Number: 80}),
+		backendIngress("app-a", "admin", "traefik", "web", networkingv1.ServiceBackendPort{Name: "admin"}),
+		backendIngress("app-b", "api", "traefik", "api", networkingv1.ServiceBackendPort{Name: "http"}),
+		backendIngress("app-b", "external", "traefik", "external", networkingv1.ServiceBackendPort{Number: 80}),
+		backendIngress("app-b", "missing", "traefik", "missing", networkingv1.ServiceBackendPort{Number: 80}),
+		backendIngress("app-c", "other-class", "nginx", "web", networkingv1.ServiceBackendPort{Number: 80}),
+		backendIngressRoute("app-b", "api",
+			map[string]any{"name": "api", "port": int64(80)},
+			map[string]any{"name": "weighted", "kind": "TraefikService"},
+		),
+	}
+
+	tests := []struct {
+		name     string
+		config   Config
+		expected []Backend
+	}{
+		{
+			name:   "all namespaces",
+			config: Config{IngressProvider: config.IngressProviderKubernetesIngress},
+			expected: []Backend{
+				{
+					Namespace:   "app-a",
+					PodSelector: map[string]string{"app": "web"},
+					Ports:       []intstr.IntOrString{intstr.FromInt32(9090), intstr.FromString("http")},
+				},
+				{
+					Namespace:   "app-b",
+					PodSelector: map[string]string{"app": "api"},
+					Ports:       []intstr.IntOrString{intstr.FromInt32(8080)},
+				},
+			},
+		},
+		{
+			name: "watched namespaces",
+			config: Config{
+				IngressProvider: config.IngressProviderKubernetesIngress,
+				Namespaces:      []string{"app-b"},
+			},
+			expected: []Backend{
+				{
+					Namespace:   "app-b",
+					PodSelector: map[string]string{"app": "api"},
+					Ports:       []intstr.IntOrString{intstr.FromInt32(8080)},
+				},
+			},
+		},
+		{
+			name: "label selector",
+			config: Config{
+				IngressProvider: config.IngressProviderKubernetesIngress,
+				LabelSelector:   "team=app-a",
+			},
+			expected: []Backend{
+				{
+					Namespace:   "app-a",
+					PodSelector: map[string]string{"app": "web"},
+					Ports:       []intstr.IntOrString{intstr.FromInt32(9090), intstr.FromString("http")},
+				},
+				{
+					Namespace:   "app-b",
+					PodSelector: map[string]string{"app": "api"},
+					Ports:       []intstr.IntOrString{intstr.FromInt32(8080)},
+				},
+			},
+		},
+		{
+			name:   "NGINX ingress class",
+			config: Config{IngressProvider: config.IngressProviderKubernetesIngressNGINX},
+			expected: []Backend{
+				{
+					Namespace:   "app-b",
+					PodSelector: map[string]string{"app": "api"},
+					Ports:       []intstr.IntOrString{intstr.FromInt32(8080)},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+			backends, err := DiscoverBackends(context.Background(), c, tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(backends, tt.expected) {
+				t.Errorf("expected backends %+v, got %+v", tt.expected, backends)
+			}
+		})
+	}
+}
+
+func TestServiceTargetPort(t *testing.T) {
+	svc := backendService("default", "web", map[string]string{"app": "web"},
+		corev1.ServicePort{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+		corev1.ServicePort{Name: "https", Port: 443, TargetPort: intstr.FromInt32(8443)},
+		corev1.ServicePort{Name: "admin", Port: 9090},
+	)
+
+	tests := []struct {
+		name     string
+		port     intstr.IntOrString
+		expected intstr.IntOrString
+		found    bool
+	}{
+		{name: "named target port", port: intstr.FromInt32(80), expected: intstr.FromString("http"), found: true},
+		{name: "numbered target port by name", port: intstr.FromString("https"), expected: intstr.FromInt32(8443), found: true},
+		{name: "defaulted target port", port: intstr.FromString("admin"), expected: intstr.FromInt32(9090), found: true},
+		{name: "unknown port", port: intstr.FromInt32(8080), found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, found := serviceTargetPort(svc, tt.port)
+			if found != tt.found {
+				t.Fatalf("expected found to be %t, got %t", tt.found, found)
+			}
+			if found && port != tt.expected {
+				t.Errorf("expected target port %s, got %s", tt.expected.String(), port.String())
+			}
+		})
+	}
+}
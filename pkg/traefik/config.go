@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config/validation"
+)
+
+// ErrConfigNotAllowed is returned when a shoot requests a setting, which is
+// not allowed by the operator configuration.
+var ErrConfigNotAllowed = errors.New("traefik config not allowed")
+
+// NewConfig returns the Traefik configuration for a shoot. The [DefaultConfig]
+// is overridden with the defaults of the given operator configuration first
+// and with the given spec of the shoot afterwards. The result is checked
+// against the limits and feature toggles of the operator configuration.
+//
+// Both the operator configuration and the spec are optional.
+func NewConfig(opCfg *config.TraefikOperatorConfiguration, spec *config.TraefikConfigSpec) (Config, error) {
+	cfg := DefaultConfig()
+	if opCfg == nil {
+		opCfg = &config.TraefikOperatorConfiguration{}
+	}
+
+	defaults := opCfg.Defaults
+	if defaults.Replicas != nil {
+		cfg.Replicas = *defaults.Replicas
+	}
+	if defaults.Resources != nil {
+		cfg.Resources = *defaults.Resources.DeepCopy()
+	}
+	if defaults.LogLevel != "" {
+		cfg.LogLevel = defaults.LogLevel
+	}
+	if defaults.IngressProvider != "" {
+		cfg.IngressProvider = defaults.IngressProvider
+	}
+	cfg.Version = defaults.Version
+
+	if spec != nil {
+		if spec.Replicas > 0 {
+			cfg.Replicas = spec.Replicas
+		}
+		if spec.IngressProvider != "" {
+			cfg.IngressProvider = spec.IngressProvider
+		}
+		if spec.LogLevel != "" {
+			cfg.LogLevel = spec.LogLevel
+		}
+		if spec.Version != "" {
+			cfg.Version = spec.Version
+		}
+		cfg.Dashboard = spec.Dashboard
+		if sd := spec.SecureDashboard; sd != nil {
+			cfg.SecureDashboard = &SecureDashboard{
+				Auth:        cmp.Or(sd.Auth, config.DashboardAuthBasicAuth),
+				ForwardAuth: sd.ForwardAuth.DeepCopy(),
+			}
+		}
+		cfg.Namespaces = slices.Clone(spec.Namespaces)
+		cfg.LabelSelector = spec.LabelSelector
+		if np := spec.NetworkPolicy; np != nil {
+			if np.Mode != "" {
+				cfg.NetworkPolicyMode = np.Mode
+			}
+			cfg.BackendNamespaceSelector = np.BackendNamespaceSelector.DeepCopy()
+			if np.MonitoringNamespace != "" {
+				cfg.MonitoringNamespace = np.MonitoringNamespace
+			}
+		}
+		cfg.DefaultMiddlewares = spec.DefaultMiddlewares.DeepCopy()
+		cfg.ErrorPages = spec.ErrorPages.DeepCopy()
+		cfg.AdditionalArguments = slices.Clone(spec.AdditionalArguments)
+		if spec.DynamicConfig != nil {
+			cfg.DynamicConfigMapName = spec.DynamicConfig.ConfigMapName
+		}
+		if spec.DeploymentMode != "" {
+			cfg.DeploymentMode = spec.DeploymentMode
+		}
+		cfg.HostPort = spec.HostPort
+		cfg.HostNetwork = spec.HostNetwork
+		if r := spec.Rollout; r != nil {
+			if r.MaxUnavailable != nil {
+				cfg.MaxUnavailable = *r.MaxUnavailable
+			}
+			if r.MaxSurge != nil {
+				cfg.MaxSurge = *r.MaxSurge
+			}
+			if r.ShutdownDelay != nil {
+				cfg.ShutdownDelay = r.ShutdownDelay.Duration
+			}
+		}
+		if h := spec.Hibernation; h != nil && h.DNSRecordPolicy != "" {
+			cfg.HibernationDNSRecordPolicy = h.DNSRecordPolicy
+		}
+		if sc := spec.Scheduling; sc != nil {
+			cfg.NodeSelector = maps.Clone(sc.NodeSelector)
+			cfg.Tolerations = slices.Clone(sc.Tolerations)
+			cfg.Affinity = sc.Affinity.DeepCopy()
+			if sc.PriorityClassName != "" {
+				cfg.PriorityClassName = sc.PriorityClassName
+			}
+		}
+	}
+
+	if _, ok := ValidLogLevels[cfg.LogLevel]; !ok {
+		return Config{}, fmt.Errorf("invalid traefik log level %q: must be one of Debug, Info, Warn, Error, Fatal, Panic", cfg.LogLevel)
+	}
+
+	// The ingress provider may be defaulted by the operator, so the scoping
+	// options are validated against the effective provider.
+	effective := &config.TraefikConfigSpec{
+		IngressProvider: cfg.IngressProvider,
+		Namespaces:      cfg.Namespaces,
+		LabelSelector:   cfg.LabelSelector,
+	}
+	if spec != nil {
+		effective.Dashboard = spec.Dashboard
+		effective.SecureDashboard = spec.SecureDashboard
+		effective.NetworkPolicy = spec.NetworkPolicy
+		effective.DefaultMiddlewares = spec.DefaultMiddlewares
+		effective.ErrorPages = spec.ErrorPages
+		effective.AdditionalArguments = spec.AdditionalArguments
+		effective.DynamicConfig = spec.DynamicConfig
+		effective.Scheduling = spec.Scheduling
+		effective.DeploymentMode = spec.DeploymentMode
+		effective.HostPort = spec.HostPort
+		effective.HostNetwork = spec.HostNetwork
+		effective.Rollout = spec.Rollout
+		effective.Hibernation = spec.Hibernation
+	}
+	if err := validation.ValidateTraefikConfigSpec(effective, field.NewPath("spec")).ToAggregate(); err != nil {
+		return Config{}, fmt.Errorf("invalid traefik config: %w", err)
+	}
+
+	limits := opCfg.Limits
+	if limits.MaxReplicas != nil && cfg.Replicas > *limits.MaxReplicas {
+		return Config{}, fmt.Errorf("%w: %d replicas exceed the maximum of %d replicas", ErrConfigNotAllowed, cfg.Replicas, *limits.MaxReplicas)
+	}
+	if len(limits.AllowedIngressProviders) > 0 && !slices.Contains(limits.AllowedIngressProviders, cfg.IngressProvider) {
+		return Config{}, fmt.Errorf("%w: ingress provider %q is not one of the allowed providers %v", ErrConfigNotAllowed, cfg.IngressProvider, limits.AllowedIngressProviders)
+	}
+
+	for _, arg := range cfg.AdditionalArguments {
+		if !ArgumentAllowed(arg, limits.AllowedArgumentPrefixes) {
+			return Config{}, fmt.Errorf("%w: argument %q does not match any of the allowed argument prefixes %v", ErrConfigNotAllowed, arg, limits.AllowedArgumentPrefixes)
+		}
+	}
+
+	features := opCfg.Features
+	if cfg.Dashboard && features.Dashboard != nil && !*features.Dashboard {
+		return Config{}, fmt.Errorf("%w: the dashboard is disabled by the operator", ErrConfigNotAllowed)
+	}
+
+	return cfg, nil
+}
+
+// ArgumentAllowed returns true, if the given additional argument matches one
+// of the given allowed argument prefixes. A prefix only matches complete
+// option names, e.g. "--entrypoints.web" matches "--entrypoints.web.address"
+// but not "--entrypoints.websecure.address", unless the prefix ends with "."
+// or "=" or is "--". Arguments are compared case-insensitively, like Traefik
+// does.
+func ArgumentAllowed(arg string, allowedPrefixes []string) bool {
+	arg = strings.ToLower(arg)
+
+	return slices.ContainsFunc(allowedPrefixes, func(prefix string) bool {
+		prefix = strings.ToLower(prefix)
+		rest, ok := strings.CutPrefix(arg, prefix)
+		if !ok {
+			return false
+		}
+
+		return rest == "" || prefix == "--" ||
+			strings.HasSuffix(prefix, ".") || strings.HasSuffix(prefix, "=") ||
+			strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "=")
+	})
+}
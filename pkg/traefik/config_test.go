@@ -0,0 +1,390 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+func TestNewConfig(t *testing.T) {
+	operatorResources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("200m"),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		opCfg         *config.TraefikOperatorConfiguration
+		spec          *config.TraefikConfigSpec
+		expected      func(cfg Config) bool
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "defaults without operator configuration",
+			expected: func(cfg Config) bool {
+				def := DefaultConfig()
+
+				return cfg.Replicas == def.Replicas && cfg.LogLevel == def.LogLevel &&
+					cfg.IngressProvider == def.IngressProvider && cfg.Resources.Limits.Memory().Equal(*def.Resources.Limits.Memory())
+			},
+		},
+		{
+			name: "operator defaults",
+			opCfg: &config.TraefikOperatorConfiguration{
+				Defaults: config.OperatorDefaults{
+					Replicas:        new(int32(3)),
+					Resources:       operatorResources,
+					LogLevel:        "Warn",
+					IngressProvider: config.IngressProviderKubernetesIngressNGINX,
+					Version:         "v3.6.11",
+				},
+			},
+			expected: func(cfg Config) bool {
+				return cfg.Replicas == 3 && cfg.LogLevel == "Warn" &&
+					cfg.IngressProvider == config.IngressProviderKubernetesIngressNGINX &&
+					cfg.Version == "v3.6.11" && cfg.Resources.Requests.Cpu().Equal(resource.MustParse("200m"))
+			},
+		},
+		{
+			name: "shoot config overrides operator defaults",
+			opCfg: &config.TraefikOperatorConfiguration{
+				Defaults: config.OperatorDefaults{
+					Replicas: new(int32(3)),
+					LogLevel: "Warn",
+					Version:  "v3.6.11",
+				},
+			},
+			spec: &config.TraefikConfigSpec{
+				Replicas:  1,
+				LogLevel:  "Debug",
+				Version:   "v3.6.13",
+				Dashboard: true,
+			},
+			expected: func(cfg Config) bool {
+				return cfg.Replicas == 1 && cfg.LogLevel == "Debug" && cfg.Version == "v3.6.13" && cfg.Dashboard
+			},
+		},
+		{
+			name: "namespaces and label selector",
+			spec: &config.TraefikConfigSpec{
+				Namespaces:    []string{"app-a", "app-b"},
+				LabelSelector: "team=a",
+			},
+			expected: func(cfg Config) bool {
+				return len(cfg.Namespaces) == 2 && cfg.LabelSelector == "team=a"
+			},
+		},
+		{
+			name: "label selector with NGINX provider defaulted by operator",
+			opCfg: &config.TraefikOperatorConfiguration{
+				Defaults: config.OperatorDefaults{IngressProvider: config.IngressProviderKubernetesIngressNGINX},
+			},
+			spec:          &config.TraefikConfigSpec{LabelSelector: "team=a"},
+			expectError:   true,
+			errorContains: "label selectors are not supported",
+		},
+		{
+			name:          "invalid namespace",
+			spec:          &config.TraefikConfigSpec{Namespaces: []string{"App_A"}},
+			expectError:   true,
+			errorContains: "spec.namespaces[0]",
+		},
+		{
+			name: "restricted network policy",
+			spec: &config.TraefikConfigSpec{
+				NetworkPolicy: &config.NetworkPolicy{Mode: config.NetworkPolicyModeRestricted},
+			},
+			expected: func(cfg Config) bool {
+				return cfg.NetworkPolicyMode == config.NetworkPolicyModeRestricted && cfg.MonitoringNamespace == "monitoring"
+			},
+		},
+		{
+			name: "unknown network policy mode",
+			spec: &config.TraefikConfigSpec{
+				NetworkPolicy: &config.NetworkPolicy{Mode: "Closed"},
+			},
+			expectError:   true,
+			errorContains: "spec.networkPolicy.mode",
+		},
+		{
+			name: "secure dashboard defaults to BasicAuth",
+			spec: &config.TraefikConfigSpec{
+				Dashboard:       true,
+				SecureDashboard: &config.SecureDashboard{},
+			},
+			expected: func(cfg Config) bool {
+				return cfg.secureDashboardEnabled() && cfg.SecureDashboard.Auth == config.DashboardAuthBasicAuth
+			},
+		},
+		{
+			name: "secure dashboard without dashboard",
+			spec: &config.TraefikConfigSpec{
+				SecureDashboard: &config.SecureDashboard{},
+			},
+			expectError:   true,
+			errorContains: "requires the dashboard to be enabled",
+		},
+		{
+			name: "default middlewares",
+			spec: &config.TraefikConfigSpec{
+				DefaultMiddlewares: &config.DefaultMiddlewares{
+					SecurityHeaders:     &config.SecurityHeaders{},
+					MaxRequestBodyBytes: new(int64(1024)),
+				},
+			},
+			expected: func(cfg Config) bool {
+				return cfg.defaultMiddlewaresEnabled() && *cfg.DefaultMiddlewares.MaxRequestBodyBytes == 1024
+			},
+		},
+		{
+			name: "invalid max request body size",
+			spec: &config.TraefikConfigSpec{
+				DefaultMiddlewares: &config.DefaultMiddlewares{MaxRequestBodyBytes: new(int64(0))},
+			},
+			expectError:   true,
+			errorContains: "spec.defaultMiddlewares.maxRequestBodyBytes",
+		},
+		{
+			name: "error pages",
+			spec: &config.TraefikConfigSpec{ErrorPages: &config.ErrorPages{}},
+			expected: func(cfg Config) bool {
+				return cfg.errorPagesEnabled() && cfg.errorPagesConfigMapName() == "traefik-error-pages"
+			},
+		},
+		{
+			name:          "invalid error pages configmap",
+			spec:          &config.TraefikConfigSpec{ErrorPages: &config.ErrorPages{ConfigMapName: "Error_Pages"}},
+			expectError:   true,
+			errorContains: "spec.errorPages.configMapName",
+		},
+		{
+			name: "dynamic config",
+			spec: &config.TraefikConfigSpec{DynamicConfig: &config.DynamicConfig{ConfigMapName: "my-dynamic-config"}},
+			expected: func(cfg Config) bool {
+				return cfg.DynamicConfigMapName == "my-dynamic-config"
+			},
+		},
+		{
+			name: "scheduling",
+			spec: &config.TraefikConfigSpec{Scheduling: &config.Scheduling{
+				NodeSelector: map[string]string{"worker.gardener.cloud/pool": "ingress"},
+				Tolerations: []corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "ingress", Effect: corev1.TaintEffectNoSchedule},
+				},
+			}},
+			expected: func(cfg Config) bool {
+				return cfg.NodeSelector["worker.gardener.cloud/pool"] == "ingress" && len(cfg.Tolerations) == 1 &&
+					cfg.PriorityClassName == DefaultPriorityClassName
+			},
+		},
+		{
+			name: "custom priority class",
+			spec: &config.TraefikConfigSpec{Scheduling: &config.Scheduling{PriorityClassName: "ingress-critical"}},
+			expected: func(cfg Config) bool {
+				return cfg.PriorityClassName == "ingress-critical"
+			},
+		},
+		{
+			name: "invalid toleration",
+			spec: &config.TraefikConfigSpec{Scheduling: &config.Scheduling{
+				Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists, Value: "ingress"}},
+			}},
+			expectError:   true,
+			errorContains: "spec.scheduling.tolerations[0].value",
+		},
+		{
+			name: "DaemonSet mode",
+			spec: &config.TraefikConfigSpec{DeploymentMode: config.DeploymentModeDaemonSet, HostPort: true},
+			expected: func(cfg Config) bool {
+				return cfg.DaemonSetMode() && cfg.HostPort && !cfg.HostNetwork
+			},
+		},
+		{
+			name:          "host network in Deployment mode",
+			spec:          &config.TraefikConfigSpec{HostNetwork: true},
+			expectError:   true,
+			errorContains: "spec.hostNetwork",
+		},
+		{
+			name: "rollout",
+			spec: &config.TraefikConfigSpec{Rollout: &config.Rollout{
+				MaxUnavailable: new(intstr.FromInt32(1)),
+				ShutdownDelay:  &metav1.Duration{Duration: 30 * time.Second},
+			}},
+			expected: func(cfg Config) bool {
+				return cfg.MaxUnavailable == intstr.FromInt32(1) && cfg.MaxSurge == intstr.FromInt32(1) && cfg.ShutdownDelay == 30*time.Second
+			},
+		},
+		{
+			name:          "rollout without surge and unavailable pods",
+			spec:          &config.TraefikConfigSpec{Rollout: &config.Rollout{MaxSurge: new(intstr.FromString("0%"))}},
+			expectError:   true,
+			errorContains: "spec.rollout.maxSurge",
+		},
+		{
+			name: "hibernation keeping the DNS record",
+			spec: &config.TraefikConfigSpec{Hibernation: &config.Hibernation{DNSRecordPolicy: config.DNSRecordPolicyKeep}},
+			expected: func(cfg Config) bool {
+				return cfg.HibernationDNSRecordPolicy == config.DNSRecordPolicyKeep
+			},
+		},
+		{
+			name:          "invalid hibernation DNS record policy",
+			spec:          &config.TraefikConfigSpec{Hibernation: &config.Hibernation{DNSRecordPolicy: "Retain"}},
+			expectError:   true,
+			errorContains: "spec.hibernation.dnsRecordPolicy",
+		},
+		{
+			name:          "dynamic config without ConfigMap name",
+			spec:          &config.TraefikConfigSpec{DynamicConfig: &config.DynamicConfig{}},
+			expectError:   true,
+			errorContains: "spec.dynamicConfig.configMapName",
+		},
+		{
+			name:          "invalid log level",
+			spec:          &config.TraefikConfigSpec{LogLevel: "Verbose"},
+			expectError:   true,
+			errorContains: "invalid traefik log level",
+		},
+		{
+			name: "replicas exceed limit",
+			opCfg: &config.TraefikOperatorConfiguration{
+				Limits: config.OperatorLimits{MaxReplicas: new(int32(3))},
+			},
+			spec:          &config.TraefikConfigSpec{Replicas: 4},
+			expectError:   true,
+			errorContains: "exceed the maximum of 3 replicas",
+		},
+		{
+			name: "ingress provider not allowed",
+			opCfg: &config.TraefikOperatorConfiguration{
+				Limits: config.OperatorLimits{
+					AllowedIngressProviders: []config.IngressProviderType{config.IngressProviderKubernetesIngress},
+				},
+			},
+			spec:          &config.TraefikConfigSpec{IngressProvider: config.IngressProviderKubernetesIngressNGINX},
+			expectError:   true,
+			errorContains: "is not one of the allowed providers",
+		},
+		{
+			name: "dashboard disabled by operator",
+			opCfg: &config.TraefikOperatorConfiguration{
+				Features: config.OperatorFeatures{Dashboard: new(false)},
+			},
+			spec:          &config.TraefikConfigSpec{Dashboard: true},
+			expectError:   true,
+			errorContains: "dashboard is disabled",
+		},
+		{
+			name: "additional arguments allowed by operator",
+			opCfg: &config.TraefikOperatorConfiguration{
+				Limits: config.OperatorLimits{AllowedArgumentPrefixes: []string{"--accesslog", "--tracing."}},
+			},
+			spec: &config.TraefikConfigSpec{AdditionalArguments: []string{"--accessLog=true", "--tracing.otlp=true"}},
+			expected: func(cfg Config) bool {
+				return len(cfg.AdditionalArguments) == 2
+			},
+		},
+		{
+			name:          "additional arguments without allowed prefixes",
+			spec:          &config.TraefikConfigSpec{AdditionalArguments: []string{"--accesslog=true"}},
+			expectError:   true,
+			errorContains: "does not match any of the allowed argument prefixes",
+		},
+		{
+			name: "additional argument only sharing a prefix with an allowed option",
+			opCfg: &config.TraefikOperatorConfiguration{
+				Limits: config.OperatorLimits{AllowedArgumentPrefixes: []string{"--tracing"}},
+			},
+			spec:          &config.TraefikConfigSpec{AdditionalArguments: []string{"--tracingfoo=true"}},
+			expectError:   true,
+			errorContains: "does not match any of the allowed argument prefixes",
+		},
+		{
+			name: "additional argument conflicting with managed arguments",
+			opCfg: &config.TraefikOperatorConfiguration{
+				Limits: config.OperatorLimits{AllowedArgumentPrefixes: []string{"--"}},
+			},
+			spec:          &config.TraefikConfigSpec{AdditionalArguments: []string{"--entryPoints.web.address=:80"}},
+			expectError:   true,
+			errorContains: "spec.additionalArguments[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewConfig(tt.opCfg, tt.spec)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if !contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error to contain %q, got: %v", tt.errorContains, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tt.expected(cfg) {
+				t.Errorf("unexpected config: %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestNewConfig_LimitErrors(t *testing.T) {
+	opCfg := &config.TraefikOperatorConfiguration{
+		Limits: config.OperatorLimits{MaxReplicas: new(int32(1))},
+	}
+
+	_, err := NewConfig(opCfg, nil)
+	if !errors.Is(err, ErrConfigNotAllowed) {
+		t.Errorf("expected ErrConfigNotAllowed for default replicas exceeding the limit, got: %v", err)
+	}
+}
+
+func TestArgumentAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      string
+		prefixes []string
+		expected bool
+	}{
+		{name: "no allowed prefixes", arg: "--accesslog=true", expected: false},
+		{name: "exact option", arg: "--accesslog", prefixes: []string{"--accesslog"}, expected: true},
+		{name: "option with value", arg: "--accesslog=true", prefixes: []string{"--accesslog"}, expected: true},
+		{name: "child option", arg: "--accesslog.format=json", prefixes: []string{"--accesslog"}, expected: true},
+		{name: "different case", arg: "--accessLog.Format=json", prefixes: []string{"--AccessLog"}, expected: true},
+		{name: "longer option name", arg: "--accesslogs=true", prefixes: []string{"--accesslog"}, expected: false},
+		{name: "sibling entrypoint", arg: "--entrypoints.websecure.address=:8443", prefixes: []string{"--entrypoints.web"}, expected: false},
+		{name: "prefix ending with a dot", arg: "--tracing.otlp=true", prefixes: []string{"--tracing."}, expected: true},
+		{name: "parent of a prefix ending with a dot", arg: "--tracing=true", prefixes: []string{"--tracing."}, expected: false},
+		{name: "prefix ending with an equal sign", arg: "--log.format=json", prefixes: []string{"--log.format="}, expected: true},
+		{name: "all options", arg: "--experimental.plugins.foo.version=v1", prefixes: []string{"--"}, expected: true},
+		{name: "any of the prefixes", arg: "--tracing.otlp=true", prefixes: []string{"--accesslog", "--tracing"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ArgumentAllowed(tt.arg, tt.prefixes); got != tt.expected {
+				t.Errorf("ArgumentAllowed(%q, %v) = %t, want %t", tt.arg, tt.prefixes, got, tt.expected)
+			}
+		})
+	}
+}
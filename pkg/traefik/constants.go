@@ -20,9 +20,30 @@ const (
 	// ImageName is the name of the Traefik image in the image vector.
 	ImageName = "traefik"
 
+	// ErrorPagesImageName is the name of the image in the image vector,
+	// which serves the error pages of the default backend.
+	ErrorPagesImageName = "error-pages"
+
 	// SeedManagedResourceName is the name of the seed-class ManagedResource
 	// that contains the DNSRecord for the Traefik ingress wildcard domain.
 	SeedManagedResourceName = "extension-traefik-ingress-dns"
+
+	// NginxCompatibilityManagedResourceName is the name of the ManagedResource,
+	// which contains the ConfigMap with the nginx annotation compatibility
+	// report.
+	NginxCompatibilityManagedResourceName = "extension-traefik-nginx-compatibility"
+
+	// NginxCompatibilityConfigMapName is the name of the ConfigMap in the shoot
+	// cluster, which contains the nginx annotation compatibility report.
+	NginxCompatibilityConfigMapName = "traefik-nginx-compatibility"
+
+	// LastKnownGoodSecretName is the name of the seed secret, which contains
+	// the last successfully rolled out configuration of Traefik.
+	LastKnownGoodSecretName = "extension-traefik-last-known-good"
+
+	// DefaultPriorityClassName is the default PriorityClass of the Traefik
+	// pods.
+	DefaultPriorityClassName = "system-cluster-critical"
 )
 
 // ValidLogLevels contains the set of log levels supported by Traefik.
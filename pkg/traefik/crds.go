@@ -0,0 +1,287 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// crdYAML contains the Traefik CRD definitions. The first line of the file
+// references the upstream Traefik release, from which the CRDs were taken.
+//
+//go:embed crds.yaml
+var crdYAML []byte
+
+// crdSourceRegexp matches the Traefik release in the source URL, which is
+// recorded at the top of crds.yaml.
+var crdSourceRegexp = regexp.MustCompile(`^#\s*https://\S+/traefik/traefik/(v\d+\.\d+\.\d+)/`)
+
+// semverRegexp matches versions with at least a major and a minor part.
+var semverRegexp = regexp.MustCompile(`^v?\d+\.\d+`)
+
+// ErrCRDVersionMismatch is returned when the Traefik version of an image is
+// not compatible with the version of the embedded CRDs.
+var ErrCRDVersionMismatch = errors.New("traefik CRD version mismatch")
+
+// CRDVersion returns the Traefik release (e.g. "v3.6.11"), from which the
+// embedded CRDs were taken.
+func CRDVersion() (string, error) {
+	line, err := bufio.NewReader(bytes.NewReader(crdYAML)).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read CRD source line: %w", err)
+	}
+
+	match := crdSourceRegexp.FindStringSubmatch(line)
+	if match == nil {
+		return "", fmt.Errorf("no traefik release found in CRD source line %q", strings.TrimSpace(line))
+	}
+
+	return match[1], nil
+}
+
+// CheckCRDCompatibility verifies that the given Traefik version can be run
+// with the embedded CRDs. Traefik only changes its CRDs with minor releases,
+// so the CRDs and the image are compatible, if their major and minor versions
+// are equal. Versions, which are not semantic versions (e.g. image digests),
+// cannot be checked and are accepted.
+func CheckCRDCompatibility(traefikVersion string) error {
+	if !semverRegexp.MatchString(traefikVersion) {
+		return nil
+	}
+
+	crdVersion, err := CRDVersion()
+	if err != nil {
+		return err
+	}
+
+	if majorMinor(crdVersion) != majorMinor(traefikVersion) {
+		return fmt.Errorf("%w: traefik %s requires CRDs from the same minor release, but embedded CRDs are from %s", ErrCRDVersionMismatch, traefikVersion, crdVersion)
+	}
+
+	return nil
+}
+
+// majorMinor returns the "<major>.<minor>" part of the given version, e.g.
+// "3.6" for "v3.6.11".
+func majorMinor(version string) string {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+
+	return parts[0] + "." + parts[1]
+}
+
+// embeddedCRDs decodes the embedded Traefik CRDs.
+func embeddedCRDs() ([]apiextensionsv1.CustomResourceDefinition, error) {
+	return decodeCRDs(crdYAML)
+}
+
+// decodeCRDs decodes all CRDs from a multi-document YAML byte slice.
+func decodeCRDs(raw []byte) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	result := make([]apiextensionsv1.CustomResourceDefinition, 0)
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(raw), 4096)
+
+	for {
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := decoder.Decode(&crd); err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return nil, fmt.Errorf("failed to decode CRD: %w", err)
+		}
+
+		if crd.Kind != "CustomResourceDefinition" || crd.Name == "" {
+			continue
+		}
+
+		result = append(result, crd)
+	}
+
+	return result, nil
+}
+
+// splitCRDs splits a multi-document YAML byte slice into individual CRD
+// documents keyed by "crd-<crdname>.yaml".
+func splitCRDs(raw []byte) (map[string][]byte, error) {
+	crds, err := decodeCRDs(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]byte, len(crds))
+	for i := range crds {
+		data, err := json.Marshal(&crds[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal CRD %s: %w", crds[i].Name, err)
+		}
+
+		result[crdResourceKey(crds[i].Name)] = data
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("no CRDs found in embedded YAML")
+	}
+
+	return result, nil
+}
+
+// crdResourceKey returns the key of the given CRD in the ManagedResource
+// secret.
+func crdResourceKey(name string) string {
+	return fmt.Sprintf("crd-%s.yaml", name)
+}
+
+// DroppedCRDVersions compares the CRDs of the currently deployed shoot
+// ManagedResource with the embedded CRDs and returns the names of the CRDs,
+// which no longer contain one of the previously deployed versions.
+//
+// Objects of these CRDs may still be persisted in a dropped version, so their
+// storage has to be migrated via [MigrateCRDStorageVersions] before the
+// embedded CRDs are applied. Otherwise the shoot API server refuses the CRD
+// update.
+func (d *Deployer) DroppedCRDVersions(ctx context.Context, namespace string) ([]string, error) {
+	deployed, err := d.DeployedResources(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(deployed) == 0 {
+		return nil, nil
+	}
+
+	desired, err := embeddedCRDs()
+	if err != nil {
+		return nil, err
+	}
+
+	dropped := make([]string, 0)
+	for i := range desired {
+		data, ok := deployed[crdResourceKey(desired[i].Name)]
+		if !ok {
+			continue
+		}
+
+		previous := &apiextensionsv1.CustomResourceDefinition{}
+		if err := json.Unmarshal(data, previous); err != nil {
+			return nil, fmt.Errorf("failed to decode deployed CRD %s: %w", desired[i].Name, err)
+		}
+
+		for _, version := range previous.Spec.Versions {
+			if !hasCRDVersion(&desired[i], version.Name) {
+				dropped = append(dropped, desired[i].Name)
+
+				break
+			}
+		}
+	}
+
+	return dropped, nil
+}
+
+// MigrateCRDStorageVersions migrates the persisted objects of the given CRDs in
+// the shoot cluster to the storage version of the live CRD and afterwards
+// removes all stored versions, which are not part of the embedded CRD, from the
+// CRD status.
+//
+// The storage version of the live CRD must also be served by the embedded CRD,
+// i.e. a CRD version cannot be introduced and dropped within a single upgrade
+// of the extension.
+func (d *Deployer) MigrateCRDStorageVersions(ctx context.Context, shootClient client.Client, names []string) error {
+	desired, err := embeddedCRDs()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		idx := slices.IndexFunc(desired, func(crd apiextensionsv1.CustomResourceDefinition) bool {
+			return crd.Name == name
+		})
+		if idx < 0 {
+			continue
+		}
+
+		if err := d.migrateCRDStorageVersion(ctx, shootClient, &desired[idx]); err != nil {
+			return fmt.Errorf("failed to migrate storage version of CRD %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Deployer) migrateCRDStorageVersion(ctx context.Context, shootClient client.Client, desired *apiextensionsv1.CustomResourceDefinition) error {
+	live := &apiextensionsv1.CustomResourceDefinition{}
+	if err := shootClient.Get(ctx, client.ObjectKey{Name: desired.Name}, live); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	stale := slices.DeleteFunc(slices.Clone(live.Status.StoredVersions), func(version string) bool {
+		return hasCRDVersion(desired, version)
+	})
+	if len(stale) == 0 {
+		return nil
+	}
+
+	storageVersion := ""
+	for _, version := range live.Spec.Versions {
+		if version.Storage {
+			storageVersion = version.Name
+		}
+	}
+	if !hasCRDVersion(desired, storageVersion) {
+		return fmt.Errorf("storage version %q is dropped by the new CRD, objects cannot be migrated", storageVersion)
+	}
+
+	d.logger.Info("migrating traefik CRD storage version", "crd", live.Name, "storageVersion", storageVersion, "staleVersions", stale)
+
+	// Re-writing every object makes the API server persist it in the current
+	// storage version.
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   live.Spec.Group,
+		Version: storageVersion,
+		Kind:    live.Spec.Names.ListKind,
+	})
+	if err := shootClient.List(ctx, list); err != nil {
+		return fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	for i := range list.Items {
+		if err := shootClient.Update(ctx, &list.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to migrate object %s: %w", client.ObjectKeyFromObject(&list.Items[i]), err)
+		}
+	}
+
+	patch := client.MergeFrom(live.DeepCopy())
+	live.Status.StoredVersions = []string{storageVersion}
+	if err := shootClient.Status().Patch(ctx, live, patch); err != nil {
+		return fmt.Errorf("failed to update stored versions: %w", err)
+	}
+
+	return nil
+}
+
+// hasCRDVersion returns true, if the given CRD contains the given version.
+func hasCRDVersion(crd *apiextensionsv1.CustomResourceDefinition, version string) bool {
+	return slices.ContainsFunc(crd.Spec.Versions, func(v apiextensionsv1.CustomResourceDefinitionVersion) bool {
+		return v.Name == version
+	})
+}
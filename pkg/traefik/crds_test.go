@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	extenimagev "github.com/gardener/gardener-extension-shoot-traefik/imagevector"
+)
+
+func TestSplitCRDs(t *testing.T) {
+	crds, err := splitCRDs(crdYAML)
+	if err != nil {
+		t.Fatalf("splitCRDs() error: %v", err)
+	}
+
+	expectedCRDs := []string{
+		"crd-ingressroutes.traefik.io.yaml",
+		"crd-ingressroutetcps.traefik.io.yaml",
+		"crd-ingressrouteudps.traefik.io.yaml",
+		"crd-middlewares.traefik.io.yaml",
+		"crd-middlewaretcps.traefik.io.yaml",
+		"crd-serverstransports.traefik.io.yaml",
+		"crd-serverstransporttcps.traefik.io.yaml",
+		"crd-tlsoptions.traefik.io.yaml",
+		"crd-tlsstores.traefik.io.yaml",
+		"crd-traefikservices.traefik.io.yaml",
+	}
+
+	if len(crds) != len(expectedCRDs) {
+		t.Errorf("expected %d CRDs, got %d", len(expectedCRDs), len(crds))
+	}
+
+	for _, name := range expectedCRDs {
+		data, ok := crds[name]
+		if !ok {
+			t.Errorf("expected CRD %q not found", name)
+
+			continue
+		}
+		if len(data) == 0 {
+			t.Errorf("CRD %q has empty data", name)
+		}
+	}
+}
+
+// TestCRDVersion_MatchesImageVector fails when crds.yaml and the Traefik images
+// in the image vector are taken from different minor releases. Update
+// crds.yaml from the release referenced at the top of the file whenever the
+// Traefik image is bumped to a new minor release.
+func TestCRDVersion_MatchesImageVector(t *testing.T) {
+	crdVersion, err := CRDVersion()
+	if err != nil {
+		t.Fatalf("CRDVersion() error: %v", err)
+	}
+
+	found := false
+	for _, src := range extenimagev.ImageVector() {
+		if src.Name != ImageName {
+			continue
+		}
+		found = true
+
+		img := src.ToImage(nil)
+		if img.Version == nil {
+			t.Fatalf("traefik image %s has no version", img.String())
+		}
+
+		if err := CheckCRDCompatibility(*img.Version); err != nil {
+			t.Errorf("crds.yaml (%s) diverges from traefik image %s: %v", crdVersion, img.String(), err)
+		}
+	}
+
+	if !found {
+		t.Fatal("no traefik image found in image vector")
+	}
+}
+
+func TestCheckCRDCompatibility(t *testing.T) {
+	crdVersion, err := CRDVersion()
+	if err != nil {
+		t.Fatalf("CRDVersion() error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		version     string
+		expectError bool
+	}{
+		{
+			name:    "same release",
+			version: crdVersion,
+		},
+		{
+			name:    "other patch release",
+			version: majorMinor(crdVersion) + ".99",
+		},
+		{
+			name:        "other minor release",
+			version:     "v1.0.0",
+			expectError: true,
+		},
+		{
+			name:    "digest is not checked",
+			version: "sha256:0123456789abcdef",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckCRDCompatibility(tt.version)
+			if tt.expectError && !errors.Is(err, ErrCRDVersionMismatch) {
+				t.Errorf("expected ErrCRDVersionMismatch, got: %v", err)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDroppedCRDVersions(t *testing.T) {
+	const namespace = "shoot--foo--bar"
+
+	// The previously deployed middlewares CRD served an additional version,
+	// which is no longer part of the embedded CRDs.
+	previous := embeddedCRD(t, "middlewares.traefik.io")
+	previous.Spec.Versions = append(previous.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
+		Name:   "v1alpha0",
+		Served: true,
+	})
+	previousData, err := json.Marshal(previous)
+	if err != nil {
+		t.Fatalf("failed to marshal CRD: %v", err)
+	}
+	unchangedData, err := json.Marshal(embeddedCRD(t, "tlsoptions.traefik.io"))
+	if err != nil {
+		t.Fatalf("failed to marshal CRD: %v", err)
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = resourcesv1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name     string
+		objects  []client.Object
+		expected []string
+	}{
+		{
+			name:     "nothing deployed yet",
+			expected: nil,
+		},
+		{
+			name: "deployed CRD drops a version",
+			objects: []client.Object{
+				&resourcesv1alpha1.ManagedResource{
+					ObjectMeta: metav1.ObjectMeta{Name: ManagedResourceName, Namespace: namespace},
+					Spec: resourcesv1alpha1.ManagedResourceSpec{
+						SecretRefs: []corev1.LocalObjectReference{{Name: "managedresource-extension-traefik"}},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "managedresource-extension-traefik", Namespace: namespace},
+					Data: map[string][]byte{
+						crdResourceKey("middlewares.traefik.io"): previousData,
+						crdResourceKey("tlsoptions.traefik.io"):  unchangedData,
+					},
+				},
+			},
+			expected: []string{"middlewares.traefik.io"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+			deployer := NewDeployer(c, logr.Discard(), DefaultConfig(), nil)
+
+			dropped, err := deployer.DroppedCRDVersions(context.Background(), namespace)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(dropped, tt.expected) && len(dropped)+len(tt.expected) > 0 {
+				t.Errorf("expected dropped CRDs %v, got %v", tt.expected, dropped)
+			}
+		})
+	}
+}
+
+func TestMigrateCRDStorageVersions(t *testing.T) {
+	live := embeddedCRD(t, "middlewares.traefik.io")
+	live.Status.StoredVersions = []string{"v1alpha0", "v1alpha1"}
+
+	gvk := schema.GroupVersionKind{Group: "traefik.io", Version: "v1alpha1", Kind: "Middleware"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(gvk, meta.RESTScopeNamespace)
+
+	middleware := &unstructured.Unstructured{}
+	middleware.SetGroupVersionKind(gvk)
+	middleware.SetNamespace("default")
+	middleware.SetName("strip-prefix")
+
+	shootClient := fake.NewClientBuilder().
+		WithScheme(ShootScheme()).
+		WithRESTMapper(mapper).
+		WithObjects(live, middleware).
+		WithStatusSubresource(live).
+		Build()
+	deployer := NewDeployer(nil, logr.Discard(), DefaultConfig(), nil)
+
+	if err := deployer.MigrateCRDStorageVersions(context.Background(), shootClient, []string{live.Name}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	migrated := &apiextensionsv1.CustomResourceDefinition{}
+	if err := shootClient.Get(context.Background(), client.ObjectKeyFromObject(live), migrated); err != nil {
+		t.Fatalf("failed to get CRD: %v", err)
+	}
+
+	if !slices.Equal(migrated.Status.StoredVersions, []string{"v1alpha1"}) {
+		t.Errorf("expected stored versions [v1alpha1], got %v", migrated.Status.StoredVersions)
+	}
+}
+
+// embeddedCRD returns the embedded CRD with the given name.
+func embeddedCRD(t *testing.T, name string) *apiextensionsv1.CustomResourceDefinition {
+	t.Helper()
+
+	crds, err := embeddedCRDs()
+	if err != nil {
+		t.Fatalf("failed to decode embedded CRDs: %v", err)
+	}
+
+	for i := range crds {
+		if crds[i].Name == name {
+			return &crds[i]
+		}
+	}
+	t.Fatalf("embedded CRD %s not found", name)
+
+	return nil
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gardener/gardener/pkg/utils"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+const (
+	// DashboardHostPrefix is the prefix of the dashboard host within the
+	// ingress domain of the shoot.
+	DashboardHostPrefix = "dashboard"
+	// DashboardUsername is the username of the generated BasicAuth
+	// credentials of the dashboard.
+	DashboardUsername = "admin"
+
+	// dashboardName is the name of the dashboard IngressRoute.
+	dashboardName = "traefik-dashboard"
+	// dashboardAuthName is the name of the dashboard authentication
+	// middleware and of the Secret containing the BasicAuth users.
+	dashboardAuthName = "traefik-dashboard-auth"
+	// dashboardPasswordLength is the length of generated dashboard passwords.
+	dashboardPasswordLength = 32
+)
+
+// SecureDashboard configures the secure exposure of the dashboard through an
+// authenticated IngressRoute.
+type SecureDashboard struct {
+	// Host is the host of the dashboard IngressRoute, see [DashboardHost].
+	Host string
+	// Auth is the authentication of the dashboard.
+	Auth config.DashboardAuthType
+	// Users are the htpasswd entries of the BasicAuth authentication, see
+	// [DashboardCredentials].
+	Users string
+	// ForwardAuth configures the forward-auth middleware of the OIDC
+	// authentication.
+	ForwardAuth *config.ForwardAuth
+}
+
+// DashboardHost returns the host of the secure dashboard for the given DNS
+// domain of a shoot. The host is covered by the wildcard DNS record of the
+// ingress domain.
+func DashboardHost(shootDomain string) string {
+	return fmt.Sprintf("%s.%s.%s", DashboardHostPrefix, gardenerutils.IngressPrefix, shootDomain)
+}
+
+// DashboardCredentials are the BasicAuth credentials of the secure dashboard.
+type DashboardCredentials struct {
+	// Username is the name of the dashboard user.
+	Username string
+	// Password is the plain text password of the dashboard user.
+	Password string
+	// Users is the htpasswd entry of the dashboard user with the bcrypt hash
+	// of the password.
+	Users string
+}
+
+// GenerateDashboardCredentials generates new [DashboardCredentials] with a
+// random password.
+func GenerateDashboardCredentials() (DashboardCredentials, error) {
+	password, err := utils.GenerateRandomString(dashboardPasswordLength)
+	if err != nil {
+		return DashboardCredentials{}, fmt.Errorf("failed to generate dashboard password: %w", err)
+	}
+
+	return NewDashboardCredentials(DashboardUsername, password)
+}
+
+// NewDashboardCredentials returns the [DashboardCredentials] for the given
+// username and password.
+func NewDashboardCredentials(username, password string) (DashboardCredentials, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return DashboardCredentials{}, fmt.Errorf("failed to hash dashboard password: %w", err)
+	}
+
+	return DashboardCredentials{
+		Username: username,
+		Password: password,
+		Users:    fmt.Sprintf("%s:%s", username, hash),
+	}, nil
+}
+
+// Valid returns true, if the htpasswd entry matches the username and the
+// password of the [DashboardCredentials].
+func (c DashboardCredentials) Valid() bool {
+	username, hash, ok := strings.Cut(c.Users, ":")
+	if !ok || c.Username == "" || username != c.Username {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(c.Password)) == nil
+}
+
+// secureDashboardEnabled returns true, if the dashboard is published through
+// an authenticated IngressRoute.
+func (c Config) secureDashboardEnabled() bool {
+	return c.Dashboard && c.SecureDashboard != nil
+}
+
+// dashboardAuthSecret returns the Secret containing the BasicAuth users of
+// the dashboard.
+func (d *Deployer) dashboardAuthSecret() *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dashboardAuthName,
+			Namespace: Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "traefik",
+				"app.kubernetes.io/managed-by": "gardener",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"users": []byte(d.config.SecureDashboard.Users),
+		},
+	}
+}
+
+// dashboardMiddleware returns the Middleware authenticating requests to the
+// dashboard.
+func (d *Deployer) dashboardMiddleware() *unstructured.Unstructured {
+	spec := map[string]any{
+		"basicAuth": map[string]any{
+			"secret":       dashboardAuthName,
+			"removeHeader": true,
+		},
+	}
+
+	if d.config.SecureDashboard.Auth == config.DashboardAuthOIDC {
+		forwardAuth := map[string]any{
+			"address":            d.config.SecureDashboard.ForwardAuth.Address,
+			"trustForwardHeader": true,
+		}
+		if headers := d.config.SecureDashboard.ForwardAuth.AuthResponseHeaders; len(headers) > 0 {
+			authResponseHeaders := make([]any, 0, len(headers))
+			for _, header := range headers {
+				authResponseHeaders = append(authResponseHeaders, header)
+			}
+			forwardAuth["authResponseHeaders"] = authResponseHeaders
+		}
+		spec = map[string]any{"forwardAuth": forwardAuth}
+	}
+
+	return traefikObject("Middleware", dashboardAuthName, spec)
+}
+
+// dashboardIngressRoute returns the IngressRoute publishing the dashboard on
+// the websecure entrypoint.
+func (d *Deployer) dashboardIngressRoute() *unstructured.Unstructured {
+	return traefikObject("IngressRoute", dashboardName, map[string]any{
+		"entryPoints": []any{"websecure"},
+		"routes": []any{
+			map[string]any{
+				"match": fmt.Sprintf("Host(`%s`)", d.config.SecureDashboard.Host),
+				"kind":  "Rule",
+				"services": []any{
+					map[string]any{
+						"name": "api@internal",
+						"kind": "TraefikService",
+					},
+				},
+				"middlewares": []any{
+					map[string]any{
+						"name": dashboardAuthName,
+					},
+				},
+			},
+		},
+		"tls": map[string]any{},
+	})
+}
+
+// traefikObject returns a Traefik custom resource of the given kind in the
+// Traefik namespace.
+func traefikObject(kind, name string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetAPIVersion("traefik.io/v1alpha1")
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace(Namespace)
+	obj.SetLabels(map[string]string{
+		"app.kubernetes.io/name":       "traefik",
+		"app.kubernetes.io/managed-by": "gardener",
+	})
+
+	return obj
+}
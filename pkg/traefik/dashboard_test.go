@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+func TestDashboardHost(t *testing.T) {
+	if host := DashboardHost("my-shoot.my-project.example.com"); host != "dashboard.ingress.my-shoot.my-project.example.com" {
+		t.Errorf("unexpected dashboard host: %s", host)
+	}
+}
+
+func TestDashboardCredentials(t *testing.T) {
+	credentials, err := GenerateDashboardCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if credentials.Username != DashboardUsername {
+		t.Errorf("expected username %q, got %q", DashboardUsername, credentials.Username)
+	}
+	if len(credentials.Password) != dashboardPasswordLength {
+		t.Errorf("expected password of length %d, got %d", dashboardPasswordLength, len(credentials.Password))
+	}
+	if !strings.HasPrefix(credentials.Users, DashboardUsername+":$2") {
+		t.Errorf("expected htpasswd entry with bcrypt hash, got %q", credentials.Users)
+	}
+	if !credentials.Valid() {
+		t.Error("expected generated credentials to be valid")
+	}
+
+	tampered := credentials
+	tampered.Password = "other"
+	if tampered.Valid() {
+		t.Error("expected credentials with a different password to be invalid")
+	}
+
+	renamed := credentials
+	renamed.Username = "other"
+	if renamed.Valid() {
+		t.Error("expected credentials with a different username to be invalid")
+	}
+
+	if (DashboardCredentials{}).Valid() {
+		t.Error("expected empty credentials to be invalid")
+	}
+}
+
+func TestDeployment_SecureDashboard(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	imageVec := imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+	}
+
+	deployer := NewDeployer(client, logr.Discard(), Config{
+		Replicas:        2,
+		IngressProvider: config.IngressProviderKubernetesIngress,
+		Dashboard:       true,
+		SecureDashboard: &SecureDashboard{
+			Host: "dashboard.ingress.example.com",
+			Auth: config.DashboardAuthBasicAuth,
+		},
+	}, imageVec)
+
+	deployment, err := deployer.deployment()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	for _, expectedArg := range []string{
+		"--api.insecure=false",
+		"--api.dashboard=true",
+		"--providers.kubernetescrd=true",
+		"--providers.kubernetescrd.namespaces=kube-system",
+	} {
+		if !slices.Contains(container.Args, expectedArg) {
+			t.Errorf("expected arg %q not found in deployment args: %v", expectedArg, container.Args)
+		}
+	}
+	if slices.Contains(container.Args, "--entrypoints.traefik.address=:9000") {
+		t.Errorf("expected insecure dashboard entrypoint to be disabled, got args: %v", container.Args)
+	}
+	for _, port := range container.Ports {
+		if port.ContainerPort == 9000 {
+			t.Errorf("expected insecure dashboard port to be absent, got ports: %v", container.Ports)
+		}
+	}
+}
+
+func TestGenerateResources_SecureDashboard(t *testing.T) {
+	imageVec := imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		dashboard        *SecureDashboard
+		namespaces       []string
+		expectSecret     bool
+		expectMiddleware string
+	}{
+		{
+			name: "BasicAuth",
+			dashboard: &SecureDashboard{
+				Host:  "dashboard.ingress.example.com",
+				Auth:  config.DashboardAuthBasicAuth,
+				Users: "admin:$2a$10$hash",
+			},
+			expectSecret:     true,
+			expectMiddleware: "basicAuth",
+		},
+		{
+			name: "OIDC",
+			dashboard: &SecureDashboard{
+				Host: "dashboard.ingress.example.com",
+				Auth: config.DashboardAuthOIDC,
+				ForwardAuth: &config.ForwardAuth{
+					Address:             "https://oauth2-proxy.example.com/oauth2/auth",
+					AuthResponseHeaders: []string{"X-Auth-Request-User"},
+				},
+			},
+			expectMiddleware: "forwardAuth",
+		},
+		{
+			name: "BasicAuth with namespace scoping",
+			dashboard: &SecureDashboard{
+				Host:  "dashboard.ingress.example.com",
+				Auth:  config.DashboardAuthBasicAuth,
+				Users: "admin:$2a$10$hash",
+			},
+			namespaces:       []string{"app-a"},
+			expectSecret:     true,
+			expectMiddleware: "basicAuth",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+			deployer := NewDeployer(client, logr.Discard(), Config{
+				Replicas:        2,
+				IngressProvider: config.IngressProviderKubernetesIngress,
+				Dashboard:       true,
+				SecureDashboard: tt.dashboard,
+				Namespaces:      tt.namespaces,
+			}, imageVec)
+
+			resources, err := deployer.generateResources()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, ok := resources["dashboard-auth-secret.yaml"]; ok != tt.expectSecret {
+				t.Errorf("expected dashboard auth secret to be present: %t", tt.expectSecret)
+			}
+
+			route := &unstructured.Unstructured{}
+			if err := route.UnmarshalJSON(resources["dashboard-ingressroute.yaml"]); err != nil {
+				t.Fatalf("failed to decode dashboard ingress route: %v", err)
+			}
+			routes, _, _ := unstructured.NestedSlice(route.Object, "spec", "routes")
+			if len(routes) != 1 || routes[0].(map[string]any)["match"] != "Host(`dashboard.ingress.example.com`)" {
+				t.Errorf("unexpected dashboard routes: %v", routes)
+			}
+			entryPoints, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "entryPoints")
+			if !slices.Equal(entryPoints, []string{"websecure"}) {
+				t.Errorf("expected dashboard to be served on the websecure entrypoint only, got: %v", entryPoints)
+			}
+
+			middleware := &unstructured.Unstructured{}
+			if err := middleware.UnmarshalJSON(resources["dashboard-middleware.yaml"]); err != nil {
+				t.Fatalf("failed to decode dashboard middleware: %v", err)
+			}
+			if _, ok, _ := unstructured.NestedMap(middleware.Object, "spec", tt.expectMiddleware); !ok {
+				t.Errorf("expected %s middleware, got: %v", tt.expectMiddleware, middleware.Object["spec"])
+			}
+
+			if len(tt.namespaces) > 0 {
+				if _, ok := resources["role-kube-system.yaml"]; !ok {
+					t.Error("expected secret access in kube-system for the dashboard middleware")
+				}
+			}
+		})
+	}
+}
@@ -7,19 +7,18 @@
 package traefik
 
 import (
-	"bytes"
 	"context"
-	_ "embed"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	extensionsv1alpha1helper "github.com/gardener/gardener/pkg/api/extensions/v1alpha1/helper"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
 	"github.com/gardener/gardener/pkg/utils/imagevector"
 	"github.com/gardener/gardener/pkg/utils/managedresources"
 	"github.com/go-logr/logr"
@@ -31,10 +30,10 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
@@ -44,12 +43,18 @@ const (
 	// ManagedResourceDeletionTimeout is the maximum duration to wait for a
 	// ManagedResource to be deleted before timing out.
 	ManagedResourceDeletionTimeout = 2 * time.Minute
-)
 
-// crdYAML contains the Traefik CRD definitions from
-//
-//go:embed crds.yaml
-var crdYAML []byte
+	// DefaultShutdownDelay is the default duration, for which a terminating
+	// Traefik pod keeps accepting requests.
+	DefaultShutdownDelay = 15 * time.Second
+	// shutdownGraceTimeout is the duration, for which Traefik waits for
+	// active requests to finish, after it stopped accepting requests.
+	shutdownGraceTimeout = 10 * time.Second
+	// terminationGraceBuffer is added to the shutdown delay and the grace
+	// timeout of Traefik for the termination grace period of the pods, so
+	// that Traefik is not killed before it finished its shutdown.
+	terminationGraceBuffer = 5 * time.Second
+)
 
 var (
 	// shootScheme is a shared scheme for encoding shoot-cluster resources.
@@ -69,6 +74,7 @@ func init() {
 	_ = rbacv1.AddToScheme(shootScheme)
 	_ = networkingv1.AddToScheme(shootScheme)
 	_ = policyv1.AddToScheme(shootScheme)
+	_ = apiextensionsv1.AddToScheme(shootScheme)
 	shootCodec = serializer.NewCodecFactory(shootScheme).LegacyCodec(
 		corev1.SchemeGroupVersion,
 		appsv1.SchemeGroupVersion,
@@ -82,6 +88,12 @@ func init() {
 	extensionsCodec = serializer.NewCodecFactory(extensionsScheme).LegacyCodec(extensionsv1alpha1.SchemeGroupVersion)
 }
 
+// ShootScheme returns the scheme, which knows about all resources the
+// extension manages in the shoot cluster.
+func ShootScheme() *runtime.Scheme {
+	return shootScheme
+}
+
 // Config holds the configuration for the Traefik deployment.
 type Config struct {
 	// Replicas is the number of Traefik replicas.
@@ -92,14 +104,111 @@ type Config struct {
 	LogLevel string
 	// Dashboard enables the Traefik dashboard on port 9000.
 	Dashboard bool
+	// SecureDashboard publishes the dashboard through an authenticated
+	// IngressRoute instead of the insecure API port 9000, if the dashboard
+	// is enabled.
+	SecureDashboard *SecureDashboard
+	// Version is the Traefik version to deploy. The default version of the
+	// image vector is deployed, if empty.
+	Version string
+	// Resources are the resource requirements of the Traefik container.
+	Resources corev1.ResourceRequirements
+	// Namespaces restricts the Ingress provider to the given namespaces. All
+	// namespaces are watched, if empty.
+	Namespaces []string
+	// LabelSelector restricts the Ingress provider to Ingress resources
+	// matching the given label selector.
+	LabelSelector string
+	// NetworkPolicyMode is the mode of the NetworkPolicy of Traefik.
+	NetworkPolicyMode config.NetworkPolicyMode
+	// BackendNamespaceSelector selects the namespaces, whose pods Traefik may
+	// reach in the Restricted NetworkPolicy mode.
+	BackendNamespaceSelector *metav1.LabelSelector
+	// MonitoringNamespace is the namespace, from which metrics may be scraped
+	// in the Restricted NetworkPolicy mode.
+	MonitoringNamespace string
+	// Backends are the backends, which Traefik may reach in the Restricted
+	// NetworkPolicy mode, if no BackendNamespaceSelector is specified. See
+	// [DiscoverBackends].
+	Backends []Backend
+	// DefaultMiddlewares are applied to all requests of the web and
+	// websecure entrypoints.
+	DefaultMiddlewares *config.DefaultMiddlewares
+	// ErrorPages deploys a default backend serving error pages, if set.
+	ErrorPages *config.ErrorPages
+	// AdditionalArguments are appended to the arguments of Traefik.
+	AdditionalArguments []string
+	// DynamicConfigMapName is the name of the ConfigMap in the Traefik
+	// namespace, which is mounted as the directory of the file provider.
+	// The file provider is disabled, if empty.
+	DynamicConfigMapName string
+	// NodeSelector restricts the Traefik pods to nodes with the given labels.
+	NodeSelector map[string]string
+	// Tolerations are the tolerations of the Traefik pods.
+	Tolerations []corev1.Toleration
+	// Affinity is the affinity of the Traefik pods.
+	Affinity *corev1.Affinity
+	// PriorityClassName is the name of the PriorityClass of the Traefik pods.
+	PriorityClassName string
+	// Zones are the availability zones of the worker pools of the shoot. The
+	// Traefik pods are spread across the zones, if there are multiple.
+	Zones []string
+	// ProgressDeadline is the duration, after which the rollout of the
+	// Deployment fails, if it does not make progress. The default deadline
+	// of Kubernetes is used, if zero.
+	ProgressDeadline time.Duration
+	// DeploymentMode specifies, whether Traefik is deployed as a Deployment
+	// or as a DaemonSet.
+	DeploymentMode config.DeploymentMode
+	// HostPort binds the web and websecure entrypoints to the ports 80 and
+	// 443 of the nodes in the DaemonSet mode.
+	HostPort bool
+	// HostNetwork runs the Traefik pods in the host network in the DaemonSet
+	// mode.
+	HostNetwork bool
+	// MaxUnavailable is the maximum number of unavailable pods during a
+	// rolling update of the Deployment.
+	MaxUnavailable intstr.IntOrString
+	// MaxSurge is the maximum number of additional pods during a rolling
+	// update of the Deployment.
+	MaxSurge intstr.IntOrString
+	// ShutdownDelay is the duration, for which a terminating Traefik pod
+	// keeps accepting requests.
+	ShutdownDelay time.Duration
+	// HibernationDNSRecordPolicy specifies, whether the ingress DNS record is
+	// deleted or kept, while the shoot is hibernated.
+	HibernationDNSRecordPolicy config.DNSRecordPolicy
+}
+
+// DaemonSetMode returns true, if Traefik is deployed as a DaemonSet.
+func (c Config) DaemonSetMode() bool {
+	return c.DeploymentMode == config.DeploymentModeDaemonSet
 }
 
 // DefaultConfig returns the default configuration for Traefik.
 func DefaultConfig() Config {
 	return Config{
-		Replicas:        2,
-		IngressProvider: config.IngressProviderKubernetesIngress,
-		LogLevel:        "Info",
+		Replicas:                   2,
+		IngressProvider:            config.IngressProviderKubernetesIngress,
+		LogLevel:                   "Info",
+		PriorityClassName:          DefaultPriorityClassName,
+		DeploymentMode:             config.DeploymentModeDeployment,
+		MaxUnavailable:             intstr.FromInt32(0),
+		MaxSurge:                   intstr.FromInt32(1),
+		ShutdownDelay:              DefaultShutdownDelay,
+		HibernationDNSRecordPolicy: config.DNSRecordPolicyDelete,
+		NetworkPolicyMode:          config.NetworkPolicyModeOpen,
+		MonitoringNamespace:        "monitoring",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+		},
 	}
 }
 
@@ -152,6 +261,31 @@ func (d *Deployer) Deploy(ctx context.Context, namespace string) error {
 	return nil
 }
 
+// DeployedResources returns the resources of the currently deployed shoot
+// ManagedResource keyed by their name in the ManagedResource secret. It returns
+// nil, if Traefik has not been deployed yet.
+func (d *Deployer) DeployedResources(ctx context.Context, namespace string) (map[string][]byte, error) {
+	mr := &resourcesv1alpha1.ManagedResource{}
+	if err := d.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ManagedResourceName}, mr); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get managed resource: %w", err)
+	}
+
+	resources := make(map[string][]byte)
+	for _, ref := range mr.Spec.SecretRefs {
+		secret := &corev1.Secret{}
+		if err := d.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get managed resource secret %s: %w", ref.Name, err)
+		}
+		maps.Copy(resources, secret.Data)
+	}
+
+	return resources, nil
+}
+
 // Delete removes Traefik from the shoot cluster.
 //
 // The resources are deleted from the shoot cluster before the ManagedResource
@@ -203,14 +337,37 @@ func (d *Deployer) deleteManagedResource(ctx context.Context, namespace string)
 //
 // Parameters:
 //   - namespace: the shoot's control-plane namespace on the seed
-//   - lbAddress: the LoadBalancer IP or hostname of the Traefik Service in the shoot
+//   - addresses: the LoadBalancer IP or hostname of the Traefik Service in the
+//     shoot, or the IPs of the nodes in the DaemonSet mode
 //   - dnsName: the fully-qualified wildcard domain, e.g. "*.ingress.my-shoot.example.com"
 //   - providerType: the DNS provider type, e.g. "aws-route53"
 //   - secretRef: reference to the DNS provider credentials secret (in the same namespace)
-func (d *Deployer) DeployDNSRecord(ctx context.Context, namespace, lbAddress, dnsName, providerType string, secretRef corev1.SecretReference) error {
-	d.logger.Info("deploying seed DNSRecord for traefik ingress", "namespace", namespace, "dnsName", dnsName, "lbAddress", lbAddress)
+func (d *Deployer) DeployDNSRecord(ctx context.Context, namespace string, addresses []string, dnsName, providerType string, secretRef corev1.SecretReference) error {
+	d.logger.Info("deploying seed DNSRecord for traefik ingress", "namespace", namespace, "dnsName", dnsName, "addresses", addresses)
+
+	resources, err := d.DNSRecordResources(namespace, addresses, dnsName, providerType, secretRef)
+	if err != nil {
+		return err
+	}
+
+	if err := managedresources.CreateForSeed(ctx, d.client, namespace, SeedManagedResourceName, false, resources); err != nil {
+		return fmt.Errorf("failed to deploy seed ManagedResource for DNSRecord: %w", err)
+	}
+
+	d.logger.Info("successfully deployed seed DNSRecord for traefik ingress", "namespace", namespace)
+
+	return nil
+}
 
-	recordType := extensionsv1alpha1helper.GetDNSRecordType(lbAddress)
+// DNSRecordResources returns the resources of the seed-class ManagedResource,
+// which [Deployer.DeployDNSRecord] deploys with the given parameters, keyed by
+// their name in the ManagedResource secret.
+func (d *Deployer) DNSRecordResources(namespace string, addresses []string, dnsName, providerType string, secretRef corev1.SecretReference) (map[string][]byte, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("no address for the DNSRecord")
+	}
+
+	recordType := extensionsv1alpha1helper.GetDNSRecordType(addresses[0])
 
 	dnsRecord := &extensionsv1alpha1.DNSRecord{
 		TypeMeta: metav1.TypeMeta{
@@ -228,24 +385,71 @@ func (d *Deployer) DeployDNSRecord(ctx context.Context, namespace, lbAddress, dn
 			SecretRef:  secretRef,
 			Name:       dnsName,
 			RecordType: recordType,
-			Values:     []string{lbAddress},
+			Values:     slices.Clone(addresses),
 		},
 	}
 
 	dnsRecordData, err := runtime.Encode(extensionsCodec, dnsRecord)
 	if err != nil {
-		return fmt.Errorf("failed to encode DNSRecord: %w", err)
+		return nil, fmt.Errorf("failed to encode DNSRecord: %w", err)
 	}
 
-	if err := managedresources.CreateForSeed(ctx, d.client, namespace, SeedManagedResourceName, false, map[string][]byte{
-		"dnsrecord.yaml": dnsRecordData,
-	}); err != nil {
-		return fmt.Errorf("failed to deploy seed ManagedResource for DNSRecord: %w", err)
+	return map[string][]byte{"dnsrecord.yaml": dnsRecordData}, nil
+}
+
+// DeployedDNSRecord returns the Traefik ingress DNSRecord of the seed-class
+// ManagedResource. It returns nil, if the DNSRecord has not been deployed yet.
+func (d *Deployer) DeployedDNSRecord(ctx context.Context, namespace string) (*extensionsv1alpha1.DNSRecord, error) {
+	mr := &resourcesv1alpha1.ManagedResource{}
+	if err := d.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: SeedManagedResourceName}, mr); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get seed ManagedResource for DNSRecord: %w", err)
 	}
 
-	d.logger.Info("successfully deployed seed DNSRecord for traefik ingress", "namespace", namespace)
+	for _, ref := range mr.Spec.SecretRefs {
+		secret := &corev1.Secret{}
+		if err := d.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get seed ManagedResource secret %s: %w", ref.Name, err)
+		}
 
-	return nil
+		data, ok := secret.Data["dnsrecord.yaml"]
+		if !ok {
+			continue
+		}
+
+		dnsRecord := &extensionsv1alpha1.DNSRecord{}
+		if err := runtime.DecodeInto(extensionsCodec, data, dnsRecord); err != nil {
+			return nil, fmt.Errorf("failed to decode DNSRecord: %w", err)
+		}
+
+		return dnsRecord, nil
+	}
+
+	return nil, nil
+}
+
+// DNSRecordDeployed returns whether the Traefik ingress DNSRecord or the
+// seed-class ManagedResource, which created it, exists in the given namespace.
+func (d *Deployer) DNSRecordDeployed(ctx context.Context, namespace string) (bool, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: SeedManagedResourceName}
+	if err := d.client.Get(ctx, key, &resourcesv1alpha1.ManagedResource{}); err == nil {
+		return true, nil
+	} else if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to get seed ManagedResource for DNSRecord: %w", err)
+	}
+
+	// The DNSRecord is left over, if its deletion failed after the
+	// ManagedResource was deleted.
+	if err := d.client.Get(ctx, key, &extensionsv1alpha1.DNSRecord{}); err == nil {
+		return true, nil
+	} else if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to get DNSRecord: %w", err)
+	}
+
+	return false, nil
 }
 
 // DeleteDNSRecord deletes the Traefik ingress DNSRecord from the seed and then
@@ -302,6 +506,13 @@ func (d *Deployer) DeleteDNSRecord(ctx context.Context, namespace string) error
 	return nil
 }
 
+// Resources returns the resources, which [Deployer.Deploy] deploys to the shoot
+// cluster, keyed by their name in the ManagedResource secret. The resources
+// are generated from the configuration only, without contacting any cluster.
+func (d *Deployer) Resources() (map[string][]byte, error) {
+	return d.generateResources()
+}
+
 // generateResources generates all Kubernetes resources for Traefik.
 func (d *Deployer) generateResources() (map[string][]byte, error) {
 	resources := make(map[string][]byte)
@@ -330,16 +541,43 @@ func (d *Deployer) generateResources() (map[string][]byte, error) {
 	}
 	resources["clusterrolebinding.yaml"] = crbData
 
-	// Deployment
-	deploy, err := d.deployment()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create deployment: %w", err)
+	// Roles and RoleBindings for scoped secret access
+	for _, namespace := range d.secretNamespaces() {
+		roleData, err := runtime.Encode(shootCodec, d.role(namespace))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode role for namespace %s: %w", namespace, err)
+		}
+		resources[fmt.Sprintf("role-%s.yaml", namespace)] = roleData
+
+		rbData, err := runtime.Encode(shootCodec, d.roleBinding(namespace))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode role binding for namespace %s: %w", namespace, err)
+		}
+		resources[fmt.Sprintf("rolebinding-%s.yaml", namespace)] = rbData
 	}
-	deployData, err := runtime.Encode(shootCodec, deploy)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode deployment: %w", err)
+
+	// Deployment or DaemonSet
+	if d.config.DaemonSetMode() {
+		ds, err := d.daemonSet()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create daemonset: %w", err)
+		}
+		dsData, err := runtime.Encode(shootCodec, ds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode daemonset: %w", err)
+		}
+		resources["daemonset.yaml"] = dsData
+	} else {
+		deploy, err := d.deployment()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create deployment: %w", err)
+		}
+		deployData, err := runtime.Encode(shootCodec, deploy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode deployment: %w", err)
+		}
+		resources["deployment.yaml"] = deployData
 	}
-	resources["deployment.yaml"] = deployData
 
 	// Service
 	svc := d.service()
@@ -373,49 +611,55 @@ func (d *Deployer) generateResources() (map[string][]byte, error) {
 	}
 	resources["poddisruptionbudget.yaml"] = pdbData
 
-	// Traefik CRDs
-	crds, err := splitCRDs(crdYAML)
-	if err != nil {
-		return nil, fmt.Errorf("failed to split traefik CRDs: %w", err)
-	}
-	maps.Copy(resources, crds)
-
-	return resources, nil
-}
-
-// splitCRDs splits a multi-document YAML byte slice into individual CRD
-// documents keyed by "crd-<crdname>.yaml".
-func splitCRDs(raw []byte) (map[string][]byte, error) {
-	result := make(map[string][]byte)
-	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(raw), 4096)
-
-	for {
-		var crd apiextensionsv1.CustomResourceDefinition
-		if err := decoder.Decode(&crd); err != nil {
-			if err == io.EOF {
-				break
+	// Secure dashboard
+	if d.config.secureDashboardEnabled() {
+		if d.config.SecureDashboard.Auth != config.DashboardAuthOIDC {
+			secretData, err := runtime.Encode(shootCodec, d.dashboardAuthSecret())
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode dashboard auth secret: %w", err)
 			}
+			resources["dashboard-auth-secret.yaml"] = secretData
+		}
 
-			return nil, fmt.Errorf("failed to decode CRD: %w", err)
+		middlewareData, err := runtime.Encode(unstructured.UnstructuredJSONScheme, d.dashboardMiddleware())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode dashboard middleware: %w", err)
 		}
+		resources["dashboard-middleware.yaml"] = middlewareData
 
-		if crd.Kind != "CustomResourceDefinition" || crd.Name == "" {
-			continue
+		routeData, err := runtime.Encode(unstructured.UnstructuredJSONScheme, d.dashboardIngressRoute())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode dashboard ingress route: %w", err)
 		}
+		resources["dashboard-ingressroute.yaml"] = routeData
+	}
 
-		data, err := json.Marshal(&crd)
+	// Default middlewares
+	for _, middleware := range d.defaultMiddlewares() {
+		middlewareData, err := runtime.Encode(unstructured.UnstructuredJSONScheme, middleware)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal CRD %s: %w", crd.Name, err)
+			return nil, fmt.Errorf("failed to encode middleware %s: %w", middleware.GetName(), err)
 		}
+		resources[fmt.Sprintf("middleware-%s.yaml", middleware.GetName())] = middlewareData
+	}
 
-		result[fmt.Sprintf("crd-%s.yaml", crd.Name)] = data
+	// Error pages
+	if d.config.errorPagesEnabled() {
+		errorPagesResources, err := d.errorPagesResources()
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(resources, errorPagesResources)
 	}
 
-	if len(result) == 0 {
-		return nil, errors.New("no CRDs found in embedded YAML")
+	// Traefik CRDs
+	crds, err := splitCRDs(crdYAML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split traefik CRDs: %w", err)
 	}
+	maps.Copy(resources, crds)
 
-	return result, nil
+	return resources, nil
 }
 
 func (d *Deployer) serviceAccount() *corev1.ServiceAccount {
@@ -438,10 +682,17 @@ func (d *Deployer) serviceAccount() *corev1.ServiceAccount {
 }
 
 func (d *Deployer) clusterRole() *rbacv1.ClusterRole {
+	// Secrets are read cluster-wide only, if Traefik watches all namespaces.
+	// Otherwise, access to secrets is granted per namespace by a Role.
+	coreResources := []string{"services", "endpoints", "secrets", "nodes"}
+	if len(d.config.Namespaces) > 0 {
+		coreResources = []string{"services", "endpoints", "nodes"}
+	}
+
 	rules := []rbacv1.PolicyRule{
 		{
 			APIGroups: []string{""},
-			Resources: []string{"services", "endpoints", "secrets", "nodes"},
+			Resources: coreResources,
 			Verbs:     []string{"get", "list", "watch"},
 		},
 		{
@@ -539,7 +790,90 @@ func (d *Deployer) clusterRoleBinding() *rbacv1.ClusterRoleBinding {
 	}
 }
 
-func (d *Deployer) deployment() (*appsv1.Deployment, error) {
+// crdProviderEnabled returns true, if the Kubernetes CRD provider is enabled
+// for the Traefik resources managed by the extension in the Traefik
+// namespace.
+func (d *Deployer) crdProviderEnabled() bool {
+	return d.config.secureDashboardEnabled() || d.config.defaultMiddlewaresEnabled() || d.config.errorPagesEnabled()
+}
+
+// secretNamespaces returns the namespaces, in which Traefik is granted access
+// to secrets by a Role. It is empty, if Traefik may read secrets cluster-wide.
+func (d *Deployer) secretNamespaces() []string {
+	if len(d.config.Namespaces) == 0 {
+		return nil
+	}
+
+	namespaces := slices.Clone(d.config.Namespaces)
+	if d.crdProviderEnabled() && !slices.Contains(namespaces, Namespace) {
+		namespaces = append(namespaces, Namespace)
+	}
+
+	return namespaces
+}
+
+// role returns the Role, which allows Traefik to read the secrets in the
+// given watched namespace.
+func (d *Deployer) role(namespace string) *rbacv1.Role {
+	return &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "Role",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "traefik-ingress-controller",
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "traefik",
+				"app.kubernetes.io/instance":   "traefik",
+				"app.kubernetes.io/managed-by": "gardener",
+			},
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+}
+
+// roleBinding returns the RoleBinding of the Traefik service account to the
+// [Deployer.role] in the given watched namespace.
+func (d *Deployer) roleBinding(namespace string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "RoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "traefik-ingress-controller",
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "traefik",
+				"app.kubernetes.io/instance":   "traefik",
+				"app.kubernetes.io/managed-by": "gardener",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "traefik-ingress-controller",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      ServiceAccountName,
+				Namespace: Namespace,
+			},
+		},
+	}
+}
+
+// podTemplate returns the pod template of Traefik, which is shared by the
+// Deployment and the DaemonSet deployment mode.
+func (d *Deployer) podTemplate() (corev1.PodTemplateSpec, error) {
 	labels := map[string]string{
 		"app.kubernetes.io/name":                 "traefik",
 		"app.kubernetes.io/instance":             "traefik",
@@ -550,18 +884,35 @@ func (d *Deployer) deployment() (*appsv1.Deployment, error) {
 	}
 
 	// Get the Traefik image from the image vector
-	img, err := d.imageVector.FindImage(ImageName)
+	img, err := FindImage(d.imageVector, d.config.Version)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find traefik image in image vector: %w", err)
+		return corev1.PodTemplateSpec{}, fmt.Errorf("failed to find traefik image in image vector: %w", err)
 	}
 	image := img.String()
 
+	if img.Version != nil {
+		if err := CheckCRDCompatibility(*img.Version); err != nil {
+			return corev1.PodTemplateSpec{}, err
+		}
+	}
+
 	// Configure Traefik arguments based on the selected provider
+	// The secure dashboard is served by an IngressRoute on the websecure
+	// entrypoint instead of the insecure API port.
+	insecureDashboard := d.config.Dashboard && !d.config.secureDashboardEnabled()
+	// The default middlewares apply to all routers of the web entrypoint,
+	// including the ping endpoint. It is served on the metrics entrypoint
+	// instead, so that an IP allowlist or a rate limit never fails the
+	// probes of the kubelet.
+	pingEntryPoint, pingPort := "web", 8000
+	if d.config.defaultMiddlewaresEnabled() {
+		pingEntryPoint, pingPort = "metrics", 9100
+	}
 	args := []string{
-		fmt.Sprintf("--api.insecure=%t", d.config.Dashboard),
+		fmt.Sprintf("--api.insecure=%t", insecureDashboard),
 		fmt.Sprintf("--api.dashboard=%t", d.config.Dashboard),
 		"--ping=true",
-		"--ping.entrypoint=web",
+		fmt.Sprintf("--ping.entrypoint=%s", pingEntryPoint),
 		"--metrics.prometheus=true",
 		"--metrics.prometheus.entrypoint=metrics",
 		"--entrypoints.web.address=:8000",
@@ -569,11 +920,26 @@ func (d *Deployer) deployment() (*appsv1.Deployment, error) {
 		"--entrypoints.metrics.address=:9100",
 		fmt.Sprintf("--log.level=%s", d.config.LogLevel),
 	}
+	args = append(args, d.lifecycleArgs()...)
 
-	if d.config.Dashboard {
+	if insecureDashboard {
 		args = append(args, "--entrypoints.traefik.address=:9000")
 	}
 
+	if d.crdProviderEnabled() {
+		args = append(args,
+			"--providers.kubernetescrd=true",
+			fmt.Sprintf("--providers.kubernetescrd.namespaces=%s", Namespace),
+		)
+	}
+
+	if middlewares := d.entryPointMiddlewares(); middlewares != "" {
+		args = append(args,
+			fmt.Sprintf("--entrypoints.web.http.middlewares=%s", middlewares),
+			fmt.Sprintf("--entrypoints.websecure.http.middlewares=%s", middlewares),
+		)
+	}
+
 	ingressClass := d.config.IngressClassName()
 
 	if d.config.IngressProvider == config.IngressProviderKubernetesIngress || d.config.IngressProvider == "" {
@@ -582,6 +948,12 @@ func (d *Deployer) deployment() (*appsv1.Deployment, error) {
 			fmt.Sprintf("--providers.kubernetesingress.ingressclass=%s", ingressClass),
 			"--providers.kubernetesingress.ingressendpoint.publishedservice=kube-system/traefik",
 		)
+		if len(d.config.Namespaces) > 0 {
+			args = append(args, fmt.Sprintf("--providers.kubernetesingress.namespaces=%s", strings.Join(d.config.Namespaces, ",")))
+		}
+		if d.config.LabelSelector != "" {
+			args = append(args, fmt.Sprintf("--providers.kubernetesingress.labelselector=%s", d.config.LabelSelector))
+		}
 	}
 
 	if d.config.IngressProvider == config.IngressProviderKubernetesIngressNGINX {
@@ -591,8 +963,41 @@ func (d *Deployer) deployment() (*appsv1.Deployment, error) {
 			fmt.Sprintf("--providers.kubernetesingressnginx.ingressclass=%s", ingressClass),
 			"--providers.kubernetesingressnginx.publishservice=kube-system/traefik",
 		)
+		// The provider supports a single namespace only, which is ensured by
+		// the validation of the config.
+		if len(d.config.Namespaces) > 0 {
+			args = append(args, fmt.Sprintf("--providers.kubernetesingressnginx.watchnamespace=%s", d.config.Namespaces[0]))
+		}
 	}
 
+	var (
+		volumes      []corev1.Volume
+		volumeMounts []corev1.VolumeMount
+	)
+	if d.config.DynamicConfigMapName != "" {
+		args = append(args,
+			fmt.Sprintf("--providers.file.directory=%s", dynamicConfigDirectory),
+			"--providers.file.watch=true",
+		)
+		volumes = append(volumes, corev1.Volume{
+			Name: dynamicConfigVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: d.config.DynamicConfigMapName},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      dynamicConfigVolumeName,
+			MountPath: dynamicConfigDirectory,
+			ReadOnly:  true,
+		})
+	}
+
+	// The additional arguments are validated not to conflict with the
+	// arguments above, see [config.TraefikConfigSpec].
+	args = append(args, d.config.AdditionalArguments...)
+
 	ports := []corev1.ContainerPort{
 		{
 			Name:          "web",
@@ -610,15 +1015,116 @@ func (d *Deployer) deployment() (*appsv1.Deployment, error) {
 			Protocol:      corev1.ProtocolTCP,
 		},
 	}
-	if d.config.Dashboard {
+	if insecureDashboard {
 		ports = append(ports, corev1.ContainerPort{
 			Name:          "traefik",
 			ContainerPort: 9000,
 			Protocol:      corev1.ProtocolTCP,
 		})
 	}
+	if d.config.DaemonSetMode() && d.config.HostPort {
+		ports[0].HostPort = 80
+		ports[1].HostPort = 443
+	}
+
+	// Pods in the host network resolve cluster-internal names only with the
+	// ClusterFirstWithHostNet DNS policy.
+	dnsPolicy := corev1.DNSClusterFirst
+	hostNetwork := d.config.DaemonSetMode() && d.config.HostNetwork
+	if hostNetwork {
+		dnsPolicy = corev1.DNSClusterFirstWithHostNet
+	}
+
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: labels,
+			Annotations: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   "9100",
+			},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: ServiceAccountName,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: new(true),
+				RunAsUser:    new(int64(65532)),
+				RunAsGroup:   new(int64(65532)),
+				FSGroup:      new(int64(65532)),
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  "traefik",
+					Image: image,
+					Args:  args,
+					Ports: ports,
+					StartupProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path: "/ping",
+								Port: intstr.FromInt(pingPort),
+							},
+						},
+						InitialDelaySeconds: 5,
+						PeriodSeconds:       5,
+						TimeoutSeconds:      3,
+						FailureThreshold:    12, // Allow up to 60 seconds for startup
+					},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path: "/ping",
+								Port: intstr.FromInt(pingPort),
+							},
+						},
+						PeriodSeconds:    10,
+						TimeoutSeconds:   5,
+						FailureThreshold: 3,
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path: "/ping",
+								Port: intstr.FromInt(pingPort),
+							},
+						},
+						PeriodSeconds:    5,
+						TimeoutSeconds:   3,
+						FailureThreshold: 3,
+					},
+					Resources: *d.config.Resources.DeepCopy(),
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: new(false),
+						ReadOnlyRootFilesystem:   new(true),
+						Capabilities: &corev1.Capabilities{
+							Drop: []corev1.Capability{"ALL"},
+						},
+					},
+					VolumeMounts: volumeMounts,
+					// Traefik logs configuration errors before it exits, which
+					// are reported as the failure reason of a rollout.
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+			},
+			Volumes:                       volumes,
+			TerminationGracePeriodSeconds: new(d.terminationGracePeriodSeconds()),
+			HostNetwork:                   hostNetwork,
+			DNSPolicy:                     dnsPolicy,
+			NodeSelector:                  maps.Clone(d.config.NodeSelector),
+			Tolerations:                   slices.Clone(d.config.Tolerations),
+			Affinity:                      d.config.Affinity.DeepCopy(),
+			PriorityClassName:             d.config.PriorityClassName,
+		},
+	}, nil
+}
+
+func (d *Deployer) deployment() (*appsv1.Deployment, error) {
+	template, err := d.podTemplate()
+	if err != nil {
+		return nil, err
+	}
+	template.Spec.TopologySpreadConstraints = d.topologySpreadConstraints()
 
-	return &appsv1.Deployment{
+	deployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apps/v1",
 			Kind:       "Deployment",
@@ -626,7 +1132,7 @@ func (d *Deployer) deployment() (*appsv1.Deployment, error) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      DeploymentName,
 			Namespace: Namespace,
-			Labels:    labels,
+			Labels:    template.Labels,
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: new(d.config.Replicas),
@@ -636,101 +1142,132 @@ func (d *Deployer) deployment() (*appsv1.Deployment, error) {
 					"app.kubernetes.io/instance": "traefik",
 				},
 			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
-					Annotations: map[string]string{
-						"prometheus.io/scrape": "true",
-						"prometheus.io/port":   "9100",
-					},
+			Template: template,
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: new(d.config.MaxUnavailable),
+					MaxSurge:       new(d.config.MaxSurge),
 				},
-				Spec: corev1.PodSpec{
-					ServiceAccountName: ServiceAccountName,
-					SecurityContext: &corev1.PodSecurityContext{
-						RunAsNonRoot: new(true),
-						RunAsUser:    new(int64(65532)),
-						RunAsGroup:   new(int64(65532)),
-						FSGroup:      new(int64(65532)),
-					},
-					Containers: []corev1.Container{
-						{
-							Name:  "traefik",
-							Image: image,
-							Args:  args,
-							Ports: ports,
-							StartupProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Path: "/ping",
-										Port: intstr.FromInt(8000),
-									},
-								},
-								InitialDelaySeconds: 5,
-								PeriodSeconds:       5,
-								TimeoutSeconds:      3,
-								FailureThreshold:    12, // Allow up to 60 seconds for startup
-							},
-							LivenessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Path: "/ping",
-										Port: intstr.FromInt(8000),
-									},
-								},
-								PeriodSeconds:    10,
-								TimeoutSeconds:   5,
-								FailureThreshold: 3,
-							},
-							ReadinessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Path: "/ping",
-										Port: intstr.FromInt(8000),
-									},
-								},
-								PeriodSeconds:    5,
-								TimeoutSeconds:   3,
-								FailureThreshold: 3,
-							},
-							Resources: corev1.ResourceRequirements{
-								Requests: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse("100m"),
-									corev1.ResourceMemory: resource.MustParse("128Mi"),
-								},
-								Limits: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse("500m"),
-									corev1.ResourceMemory: resource.MustParse("512Mi"),
-								},
-							},
-							SecurityContext: &corev1.SecurityContext{
-								AllowPrivilegeEscalation: new(false),
-								ReadOnlyRootFilesystem:   new(true),
-								Capabilities: &corev1.Capabilities{
-									Drop: []corev1.Capability{"ALL"},
-								},
-							},
-						},
-					},
-					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
-						{
-							MaxSkew:           1,
-							TopologyKey:       "kubernetes.io/hostname",
-							WhenUnsatisfiable: corev1.ScheduleAnyway,
-							LabelSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									"app.kubernetes.io/name":     "traefik",
-									"app.kubernetes.io/instance": "traefik",
-								},
-							},
-						},
-					},
+			},
+		},
+	}
+
+	if d.config.ProgressDeadline > 0 {
+		deployment.Spec.ProgressDeadlineSeconds = new(int32(d.config.ProgressDeadline.Seconds()))
+	}
+
+	return deployment, nil
+}
+
+// daemonSet returns the DaemonSet of the DaemonSet deployment mode. Pods are
+// replaced one node at a time without a surge, because a surge pod cannot
+// bind the host ports or the host network ports of the replaced pod.
+func (d *Deployer) daemonSet() (*appsv1.DaemonSet, error) {
+	template, err := d.podTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "DaemonSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeploymentName,
+			Namespace: Namespace,
+			Labels:    template.Labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":     "traefik",
+					"app.kubernetes.io/instance": "traefik",
+				},
+			},
+			Template: template,
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: new(intstr.FromInt32(1)),
+					MaxSurge:       new(intstr.FromInt32(0)),
 				},
 			},
 		},
 	}, nil
 }
 
+// lifecycleArgs returns the arguments for the graceful shutdown of Traefik.
+// On termination, Traefik keeps accepting requests on the web and websecure
+// entrypoints for the shutdown delay, while the ping endpoint fails the
+// readiness probe, so that the pod is removed from the endpoints of the
+// Service and the load balancer before it stops accepting requests. Active
+// requests are given the grace timeout to finish afterwards. This replaces a
+// preStop hook, which would require a shell in the Traefik image.
+func (d *Deployer) lifecycleArgs() []string {
+	args := []string{"--ping.terminatingstatuscode=503"}
+	for _, entryPoint := range []string{"web", "websecure"} {
+		args = append(args,
+			fmt.Sprintf("--entrypoints.%s.transport.lifecycle.requestacceptgracetimeout=%s", entryPoint, d.config.ShutdownDelay),
+			fmt.Sprintf("--entrypoints.%s.transport.lifecycle.gracetimeout=%s", entryPoint, shutdownGraceTimeout),
+		)
+	}
+
+	return args
+}
+
+// terminationGracePeriodSeconds returns the termination grace period of the
+// Traefik pods, which covers the shutdown delay and the grace timeout.
+func (d *Deployer) terminationGracePeriodSeconds() int64 {
+	return int64((d.config.ShutdownDelay + shutdownGraceTimeout + terminationGraceBuffer).Seconds())
+}
+
+// topologySpreadConstraints spreads the Traefik pods across the nodes and, if
+// the worker pools of the shoot span multiple zones, across the zones. Both
+// constraints are soft, so that the pods can still be scheduled if the nodes
+// are restricted, e.g. by a node selector.
+func (d *Deployer) topologySpreadConstraints() []corev1.TopologySpreadConstraint {
+	topologyKeys := []string{corev1.LabelHostname}
+	if len(d.config.Zones) > 1 {
+		topologyKeys = append(topologyKeys, corev1.LabelTopologyZone)
+	}
+
+	constraints := make([]corev1.TopologySpreadConstraint, 0, len(topologyKeys))
+	for _, topologyKey := range topologyKeys {
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":     "traefik",
+					"app.kubernetes.io/instance": "traefik",
+				},
+			},
+		})
+	}
+
+	return constraints
+}
+
+// service returns the Service of Traefik. It is of type LoadBalancer, or of
+// type NodePort in the DaemonSet mode, which is used without a working
+// LoadBalancer implementation.
+//
+// The external traffic policy is Local, if a default middleware acts on the
+// client IP, because the source of requests is translated to the IP of a node
+// with the Cluster policy.
 func (d *Deployer) service() *corev1.Service {
+	serviceType := corev1.ServiceTypeLoadBalancer
+	if d.config.DaemonSetMode() {
+		serviceType = corev1.ServiceTypeNodePort
+	}
+	externalTrafficPolicy := corev1.ServiceExternalTrafficPolicyCluster
+	if d.config.clientIPMiddlewaresEnabled() {
+		externalTrafficPolicy = corev1.ServiceExternalTrafficPolicyLocal
+	}
+
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
@@ -747,7 +1284,8 @@ func (d *Deployer) service() *corev1.Service {
 			},
 		},
 		Spec: corev1.ServiceSpec{
-			Type: corev1.ServiceTypeLoadBalancer,
+			Type:                  serviceType,
+			ExternalTrafficPolicy: externalTrafficPolicy,
 			Selector: map[string]string{
 				"app.kubernetes.io/name":     "traefik",
 				"app.kubernetes.io/instance": "traefik",
@@ -807,6 +1345,11 @@ func (d *Deployer) ingressClass() *networkingv1.IngressClass {
 }
 
 func (d *Deployer) networkPolicy() *networkingv1.NetworkPolicy {
+	ingress, egress := d.openNetworkPolicyRules()
+	if d.config.NetworkPolicyMode == config.NetworkPolicyModeRestricted {
+		ingress, egress = d.restrictedNetworkPolicyRules()
+	}
+
 	return &networkingv1.NetworkPolicy{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "networking.k8s.io/v1",
@@ -831,35 +1374,135 @@ func (d *Deployer) networkPolicy() *networkingv1.NetworkPolicy {
 				networkingv1.PolicyTypeIngress,
 				networkingv1.PolicyTypeEgress,
 			},
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
+			Ingress: ingress,
+			Egress:  egress,
+		},
+	}
+}
+
+// openNetworkPolicyRules returns the NetworkPolicy rules of the Open mode.
+func (d *Deployer) openNetworkPolicyRules() ([]networkingv1.NetworkPolicyIngressRule, []networkingv1.NetworkPolicyEgressRule) {
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{
+			// Allow all ingress traffic to Traefik from anywhere
+			// This is required for the LoadBalancer to reach Traefik pods
+		},
+	}
+	// Allow all egress traffic from Traefik to anywhere
+	// This is required for Traefik to reach backend pods behind Ingress resources.
+	// The Restricted mode allows egress traffic to the backends only, but it
+	// may require users to configure the backends explicitly.
+	egress := []networkingv1.NetworkPolicyEgressRule{
+		{
+			// Allow egress to all pods in all namespaces
+			// This is required for Traefik to reach backend pods behind Ingress resources
+			// Note: DNS and API server access is already granted via Gardener's policies
+			// (gardener.cloud--allow-to-dns and gardener.cloud--allow-to-apiserver)
+			// which match pods with the corresponding labels on the Traefik deployment
+			To: []networkingv1.NetworkPolicyPeer{
 				{
-					// Allow all ingress traffic to Traefik from anywhere
-					// This is required for the LoadBalancer to reach Traefik pods
+					NamespaceSelector: &metav1.LabelSelector{},
+					PodSelector:       &metav1.LabelSelector{},
 				},
 			},
-			// Allow all egress traffic from Traefik to anywhere
-			// This is required for Traefik to reach backend pods behind Ingress resources.
-			// Think about making this configurable in the future if we want to be more restrictive, but it would require users to add additional policies to allow traffic to their backend pods
-			Egress: []networkingv1.NetworkPolicyEgressRule{
+		},
+	}
+
+	return ingress, egress
+}
+
+// restrictedNetworkPolicyRules returns the NetworkPolicy rules of the
+// Restricted mode. Ingress traffic is allowed to the entrypoint ports from
+// anywhere and to the metrics port from the monitoring namespace only. Egress
+// traffic is allowed to the pods of the namespaces selected by the backend
+// namespace selector, or to the discovered backends otherwise.
+func (d *Deployer) restrictedNetworkPolicyRules() ([]networkingv1.NetworkPolicyIngressRule, []networkingv1.NetworkPolicyEgressRule) {
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				networkPolicyPort(intstr.FromInt32(8000)),
+				networkPolicyPort(intstr.FromInt32(8443)),
+			},
+		},
+		{
+			From: []networkingv1.NetworkPolicyPeer{
 				{
-					// Allow egress to all pods in all namespaces
-					// This is required for Traefik to reach backend pods behind Ingress resources
-					// Note: DNS and API server access is already granted via Gardener's policies
-					// (gardener.cloud--allow-to-dns and gardener.cloud--allow-to-apiserver)
-					// which match pods with the corresponding labels on the Traefik deployment
-					To: []networkingv1.NetworkPolicyPeer{
-						{
-							NamespaceSelector: &metav1.LabelSelector{},
-							PodSelector:       &metav1.LabelSelector{},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							corev1.LabelMetadataName: d.config.MonitoringNamespace,
 						},
 					},
 				},
 			},
+			Ports: []networkingv1.NetworkPolicyPort{
+				networkPolicyPort(intstr.FromInt32(9100)),
+			},
 		},
 	}
+
+	// DNS and API server access is granted via Gardener's policies, see
+	// [Deployer.openNetworkPolicyRules].
+	egress := []networkingv1.NetworkPolicyEgressRule{}
+	if d.config.errorPagesEnabled() {
+		egress = append(egress, errorPagesEgressRule())
+	}
+	if d.config.BackendNamespaceSelector != nil {
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: d.config.BackendNamespaceSelector.DeepCopy(),
+				},
+			},
+		})
+
+		return ingress, egress
+	}
+
+	for _, backend := range d.config.Backends {
+		rule := networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							corev1.LabelMetadataName: backend.Namespace,
+						},
+					},
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: maps.Clone(backend.PodSelector),
+					},
+				},
+			},
+		}
+		for _, port := range backend.Ports {
+			rule.Ports = append(rule.Ports, networkPolicyPort(port))
+		}
+		egress = append(egress, rule)
+	}
+
+	return ingress, egress
 }
 
+// networkPolicyPort returns a TCP [networkingv1.NetworkPolicyPort] for the
+// given port.
+func networkPolicyPort(port intstr.IntOrString) networkingv1.NetworkPolicyPort {
+	return networkingv1.NetworkPolicyPort{
+		Protocol: new(corev1.ProtocolTCP),
+		Port:     &port,
+	}
+}
+
+// podDisruptionBudget returns the PodDisruptionBudget of Traefik. In the
+// DaemonSet mode, the number of pods depends on the number of nodes, so a
+// single pod may be disrupted at a time instead of keeping a single pod
+// available.
 func (d *Deployer) podDisruptionBudget() *policyv1.PodDisruptionBudget {
+	var minAvailable, maxUnavailable *intstr.IntOrString
+	if d.config.DaemonSetMode() {
+		maxUnavailable = &intstr.IntOrString{Type: intstr.Int, IntVal: 1}
+	} else {
+		minAvailable = &intstr.IntOrString{Type: intstr.Int, IntVal: 1}
+	}
+
 	return &policyv1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "policy/v1",
@@ -875,7 +1518,8 @@ func (d *Deployer) podDisruptionBudget() *policyv1.PodDisruptionBudget {
 			},
 		},
 		Spec: policyv1.PodDisruptionBudgetSpec{
-			MinAvailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
 					"app.kubernetes.io/name":     "traefik",
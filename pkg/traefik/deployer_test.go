@@ -5,13 +5,24 @@
 package traefik
 
 import (
+	"context"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
 	"github.com/gardener/gardener/pkg/utils/imagevector"
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
@@ -21,6 +32,7 @@ func TestDeployment_ImageOverride(t *testing.T) {
 	tests := []struct {
 		name          string
 		imageVector   imagevector.ImageVector
+		version       string
 		expectedImage string
 		expectError   bool
 		errorContains string
@@ -44,6 +56,20 @@ func TestDeployment_ImageOverride(t *testing.T) {
 			expectError:   true,
 			errorContains: "failed to find traefik image",
 		},
+		{
+			name:          "use pinned version",
+			imageVector:   testVersionedImageVector(),
+			version:       "v3.6.11",
+			expectedImage: "docker.io/library/traefik:v3.6.11",
+			expectError:   false,
+		},
+		{
+			name:          "fail when pinned version not in vector",
+			imageVector:   testVersionedImageVector(),
+			version:       "v3.5.0",
+			expectError:   true,
+			errorContains: "unsupported traefik version",
+		},
 	}
 
 	for _, tt := range tests {
@@ -54,6 +80,7 @@ func TestDeployment_ImageOverride(t *testing.T) {
 
 			config := Config{
 				Replicas: 2,
+				Version:  tt.version,
 			}
 
 			deployer := NewDeployer(client, logr.Discard(), config, tt.imageVector)
@@ -471,6 +498,258 @@ func TestClusterRole_RBAC_Permissions(t *testing.T) {
 	}
 }
 
+func TestDeployment_NamespaceScoping(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          Config
+		expectedArgs    []string
+		notExpectedArgs []string
+	}{
+		{
+			name:   "all namespaces by default",
+			config: Config{IngressProvider: config.IngressProviderKubernetesIngress},
+			notExpectedArgs: []string{
+				"--providers.kubernetesingress.namespaces",
+				"--providers.kubernetesingress.labelselector",
+			},
+		},
+		{
+			name: "KubernetesIngress provider with namespaces and label selector",
+			config: Config{
+				IngressProvider: config.IngressProviderKubernetesIngress,
+				Namespaces:      []string{"app-a", "app-b"},
+				LabelSelector:   "team=a",
+			},
+			expectedArgs: []string{
+				"--providers.kubernetesingress.namespaces=app-a,app-b",
+				"--providers.kubernetesingress.labelselector=team=a",
+			},
+		},
+		{
+			name: "KubernetesIngressNGINX provider with namespace",
+			config: Config{
+				IngressProvider: config.IngressProviderKubernetesIngressNGINX,
+				Namespaces:      []string{"app-a"},
+			},
+			expectedArgs: []string{
+				"--providers.kubernetesingressnginx.watchnamespace=app-a",
+			},
+			notExpectedArgs: []string{
+				"--providers.kubernetesingress.namespaces",
+			},
+		},
+		{
+			name: "additional arguments",
+			config: Config{
+				IngressProvider:     config.IngressProviderKubernetesIngress,
+				AdditionalArguments: []string{"--accesslog=true", "--accesslog.format=json"},
+			},
+			expectedArgs: []string{
+				"--accesslog=true",
+				"--accesslog.format=json",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+			imageVec := imagevector.ImageVector{
+				{
+					Name:       "traefik",
+					Repository: new("docker.io/library/traefik"),
+					Tag:        new("v3.6.10"),
+				},
+			}
+
+			deployer := NewDeployer(client, logr.Discard(), tt.config, imageVec)
+			deployment, err := deployer.deployment()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := deployment.Spec.Template.Spec.Containers[0].Args
+			for _, expectedArg := range tt.expectedArgs {
+				if !slices.Contains(args, expectedArg) {
+					t.Errorf("expected arg %q not found in deployment args: %v", expectedArg, args)
+				}
+			}
+			for _, notExpectedArg := range tt.notExpectedArgs {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, notExpectedArg) {
+						t.Errorf("unexpected arg %q found in deployment args: %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRBAC_NamespaceScoping(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	imageVec := imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+	}
+
+	deployer := NewDeployer(client, logr.Discard(), Config{
+		Replicas:        2,
+		IngressProvider: config.IngressProviderKubernetesIngress,
+		Namespaces:      []string{"app-a", "app-b"},
+	}, imageVec)
+
+	for _, rule := range deployer.clusterRole().Rules {
+		if slices.Contains(rule.Resources, "secrets") {
+			t.Errorf("expected no cluster-wide secret access with namespace scoping, got rule: %+v", rule)
+		}
+	}
+
+	resources, err := deployer.generateResources()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, namespace := range []string{"app-a", "app-b"} {
+		for _, key := range []string{"role-" + namespace + ".yaml", "rolebinding-" + namespace + ".yaml"} {
+			if _, ok := resources[key]; !ok {
+				t.Errorf("expected resource %q not found", key)
+			}
+		}
+
+		role := deployer.role(namespace)
+		if role.Namespace != namespace {
+			t.Errorf("expected role in namespace %q, got %q", namespace, role.Namespace)
+		}
+		if len(role.Rules) != 1 || !slices.Equal(role.Rules[0].Resources, []string{"secrets"}) {
+			t.Errorf("expected role to grant access to secrets only, got: %+v", role.Rules)
+		}
+
+		roleBinding := deployer.roleBinding(namespace)
+		if roleBinding.RoleRef.Kind != "Role" || roleBinding.RoleRef.Name != role.Name {
+			t.Errorf("expected role binding to reference role %q, got: %+v", role.Name, roleBinding.RoleRef)
+		}
+		if roleBinding.Subjects[0].Namespace != Namespace || roleBinding.Subjects[0].Name != ServiceAccountName {
+			t.Errorf("expected role binding subject to be the traefik service account, got: %+v", roleBinding.Subjects[0])
+		}
+	}
+}
+
+func TestNetworkPolicy_Modes(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		verify func(t *testing.T, spec networkingv1.NetworkPolicySpec)
+	}{
+		{
+			name:   "Open mode allows all traffic",
+			config: DefaultConfig(),
+			verify: func(t *testing.T, spec networkingv1.NetworkPolicySpec) {
+				t.Helper()
+				if len(spec.Ingress) != 1 || len(spec.Ingress[0].Ports) != 0 || len(spec.Ingress[0].From) != 0 {
+					t.Errorf("expected a single ingress rule allowing all traffic, got: %+v", spec.Ingress)
+				}
+				if len(spec.Egress) != 1 || len(spec.Egress[0].To) != 1 || len(spec.Egress[0].To[0].NamespaceSelector.MatchLabels) != 0 {
+					t.Errorf("expected a single egress rule allowing all pods, got: %+v", spec.Egress)
+				}
+			},
+		},
+		{
+			name: "Restricted mode with backend namespace selector",
+			config: Config{
+				NetworkPolicyMode:   config.NetworkPolicyModeRestricted,
+				MonitoringNamespace: "monitoring",
+				BackendNamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"ingress": "traefik"},
+				},
+			},
+			verify: func(t *testing.T, spec networkingv1.NetworkPolicySpec) {
+				t.Helper()
+				if len(spec.Ingress) != 2 {
+					t.Fatalf("expected two ingress rules, got: %+v", spec.Ingress)
+				}
+				if len(spec.Ingress[0].From) != 0 || len(spec.Ingress[0].Ports) != 2 ||
+					spec.Ingress[0].Ports[0].Port.IntValue() != 8000 || spec.Ingress[0].Ports[1].Port.IntValue() != 8443 {
+					t.Errorf("expected entrypoint ports to be allowed from anywhere, got: %+v", spec.Ingress[0])
+				}
+				metrics := spec.Ingress[1]
+				if len(metrics.Ports) != 1 || metrics.Ports[0].Port.IntValue() != 9100 ||
+					metrics.From[0].NamespaceSelector.MatchLabels[corev1.LabelMetadataName] != "monitoring" {
+					t.Errorf("expected metrics port to be allowed from the monitoring namespace, got: %+v", metrics)
+				}
+				if len(spec.Egress) != 1 || spec.Egress[0].To[0].NamespaceSelector.MatchLabels["ingress"] != "traefik" ||
+					spec.Egress[0].To[0].PodSelector != nil {
+					t.Errorf("expected egress to the selected namespaces, got: %+v", spec.Egress)
+				}
+			},
+		},
+		{
+			name: "Restricted mode with discovered backends",
+			config: Config{
+				NetworkPolicyMode:   config.NetworkPolicyModeRestricted,
+				MonitoringNamespace: "observability",
+				Backends: []Backend{
+					{
+						Namespace:   "app-a",
+						PodSelector: map[string]string{"app": "web"},
+						Ports:       []intstr.IntOrString{intstr.FromString("http")},
+					},
+					{
+						Namespace:   "app-b",
+						PodSelector: map[string]string{"app": "api"},
+					},
+				},
+			},
+			verify: func(t *testing.T, spec networkingv1.NetworkPolicySpec) {
+				t.Helper()
+				if spec.Ingress[1].From[0].NamespaceSelector.MatchLabels[corev1.LabelMetadataName] != "observability" {
+					t.Errorf("expected metrics to be allowed from the configured monitoring namespace, got: %+v", spec.Ingress[1])
+				}
+				if len(spec.Egress) != 2 {
+					t.Fatalf("expected an egress rule per backend, got: %+v", spec.Egress)
+				}
+				web := spec.Egress[0]
+				if web.To[0].NamespaceSelector.MatchLabels[corev1.LabelMetadataName] != "app-a" ||
+					web.To[0].PodSelector.MatchLabels["app"] != "web" ||
+					len(web.Ports) != 1 || web.Ports[0].Port.String() != "http" {
+					t.Errorf("unexpected egress rule for backend app-a: %+v", web)
+				}
+				if api := spec.Egress[1]; len(api.Ports) != 0 {
+					t.Errorf("expected all ports to be allowed for backend app-b, got: %+v", api)
+				}
+			},
+		},
+		{
+			name: "Restricted mode without backends denies egress",
+			config: Config{
+				NetworkPolicyMode:   config.NetworkPolicyModeRestricted,
+				MonitoringNamespace: "monitoring",
+			},
+			verify: func(t *testing.T, spec networkingv1.NetworkPolicySpec) {
+				t.Helper()
+				if spec.Egress == nil || len(spec.Egress) != 0 {
+					t.Errorf("expected an empty list of egress rules, got: %+v", spec.Egress)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+			deployer := NewDeployer(client, logr.Discard(), tt.config, nil)
+
+			np := deployer.networkPolicy()
+			if !slices.Equal(np.Spec.PolicyTypes, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}) {
+				t.Errorf("unexpected policy types: %v", np.Spec.PolicyTypes)
+			}
+			tt.verify(t, np.Spec)
+		})
+	}
+}
+
 func TestDefaultConfig(t *testing.T) {
 	defaultCfg := DefaultConfig()
 	if defaultCfg.Replicas != 2 {
@@ -484,6 +763,127 @@ func TestDefaultConfig(t *testing.T) {
 	if defaultCfg.IngressProvider != config.IngressProviderKubernetesIngress {
 		t.Errorf("expected default ingress provider to be 'KubernetesIngress', got %q", defaultCfg.IngressProvider)
 	}
+
+	if defaultCfg.PriorityClassName != "system-cluster-critical" {
+		t.Errorf("expected default priority class to be 'system-cluster-critical', got %q", defaultCfg.PriorityClassName)
+	}
+}
+
+func TestDeployment_Scheduling(t *testing.T) {
+	imageVec := imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+	}
+
+	affinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: "node.kubernetes.io/instance-type", Operator: corev1.NodeSelectorOpIn, Values: []string{"m5.large"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		modify          func(cfg *Config)
+		expectZoneSplit bool
+		verify          func(t *testing.T, spec corev1.PodSpec)
+	}{
+		{
+			name: "defaults",
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				if spec.PriorityClassName != "system-cluster-critical" {
+					t.Errorf("expected priority class 'system-cluster-critical', got %q", spec.PriorityClassName)
+				}
+				if spec.NodeSelector != nil || spec.Tolerations != nil || spec.Affinity != nil {
+					t.Errorf("expected no scheduling restrictions, got node selector %v, tolerations %v, affinity %v", spec.NodeSelector, spec.Tolerations, spec.Affinity)
+				}
+			},
+		},
+		{
+			name: "single zone",
+			modify: func(cfg *Config) {
+				cfg.Zones = []string{"eu-west-1a"}
+			},
+		},
+		{
+			name: "multiple zones",
+			modify: func(cfg *Config) {
+				cfg.Zones = []string{"eu-west-1a", "eu-west-1b"}
+			},
+			expectZoneSplit: true,
+		},
+		{
+			name: "dedicated worker pool",
+			modify: func(cfg *Config) {
+				cfg.NodeSelector = map[string]string{"worker.gardener.cloud/pool": "ingress"}
+				cfg.Tolerations = []corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "ingress", Effect: corev1.TaintEffectNoSchedule},
+				}
+				cfg.Affinity = affinity
+				cfg.PriorityClassName = "ingress-critical"
+			},
+			verify: func(t *testing.T, spec corev1.PodSpec) {
+				if spec.NodeSelector["worker.gardener.cloud/pool"] != "ingress" {
+					t.Errorf("unexpected node selector: %v", spec.NodeSelector)
+				}
+				if len(spec.Tolerations) != 1 || spec.Tolerations[0].Key != "dedicated" {
+					t.Errorf("unexpected tolerations: %v", spec.Tolerations)
+				}
+				if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity == affinity {
+					t.Errorf("expected a copy of the node affinity, got: %v", spec.Affinity)
+				}
+				if spec.PriorityClassName != "ingress-critical" {
+					t.Errorf("expected priority class 'ingress-critical', got %q", spec.PriorityClassName)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			if tt.modify != nil {
+				tt.modify(&cfg)
+			}
+			client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+			deployer := NewDeployer(client, logr.Discard(), cfg, imageVec)
+
+			deployment, err := deployer.deployment()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			spec := deployment.Spec.Template.Spec
+			topologyKeys := []string{}
+			for _, constraint := range spec.TopologySpreadConstraints {
+				if constraint.WhenUnsatisfiable != corev1.ScheduleAnyway {
+					t.Errorf("expected soft topology spread constraint for %s", constraint.TopologyKey)
+				}
+				topologyKeys = append(topologyKeys, constraint.TopologyKey)
+			}
+			expectedKeys := []string{"kubernetes.io/hostname"}
+			if tt.expectZoneSplit {
+				expectedKeys = append(expectedKeys, "topology.kubernetes.io/zone")
+			}
+			if !slices.Equal(topologyKeys, expectedKeys) {
+				t.Errorf("expected topology spread across %v, got %v", expectedKeys, topologyKeys)
+			}
+
+			if tt.verify != nil {
+				tt.verify(t, spec)
+			}
+		})
+	}
 }
 
 func TestIngressClass_Controller(t *testing.T) {
@@ -582,38 +982,354 @@ func TestIngressClassName(t *testing.T) {
 	}
 }
 
-func TestSplitCRDs(t *testing.T) {
-	crds, err := splitCRDs(crdYAML)
+func TestService_ExternalTrafficPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		middlewares *config.DefaultMiddlewares
+		mode        config.DeploymentMode
+		expected    corev1.ServiceExternalTrafficPolicy
+	}{
+		{
+			name:     "no default middlewares",
+			expected: corev1.ServiceExternalTrafficPolicyCluster,
+		},
+		{
+			name: "middlewares independent of the client IP",
+			middlewares: &config.DefaultMiddlewares{
+				SecurityHeaders:     &config.SecurityHeaders{},
+				MaxRequestBodyBytes: new(int64(1024)),
+			},
+			expected: corev1.ServiceExternalTrafficPolicyCluster,
+		},
+		{
+			name:        "IP allowlist",
+			middlewares: &config.DefaultMiddlewares{IPAllowList: &config.IPAllowList{SourceRange: []string{"10.0.0.0/8"}}},
+			expected:    corev1.ServiceExternalTrafficPolicyLocal,
+		},
+		{
+			name:        "rate limit",
+			middlewares: &config.DefaultMiddlewares{RateLimit: &config.RateLimit{Average: 100}},
+			expected:    corev1.ServiceExternalTrafficPolicyLocal,
+		},
+		{
+			name:        "rate limit in the DaemonSet mode",
+			middlewares: &config.DefaultMiddlewares{RateLimit: &config.RateLimit{Average: 100}},
+			mode:        config.DeploymentModeDaemonSet,
+			expected:    corev1.ServiceExternalTrafficPolicyLocal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.DefaultMiddlewares = tt.middlewares
+			if tt.mode != "" {
+				cfg.DeploymentMode = tt.mode
+			}
+			deployer := NewDeployer(nil, logr.Discard(), cfg, nil)
+
+			if got := deployer.service().Spec.ExternalTrafficPolicy; got != tt.expected {
+				t.Errorf("expected external traffic policy %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenerateResources_DaemonSetMode(t *testing.T) {
+	imageVec := imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		hostPort    bool
+		hostNetwork bool
+	}{
+		{name: "NodePort only"},
+		{name: "host ports", hostPort: true},
+		{name: "host network", hostNetwork: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.DeploymentMode = config.DeploymentModeDaemonSet
+			cfg.HostPort = tt.hostPort
+			cfg.HostNetwork = tt.hostNetwork
+			cfg.Zones = []string{"eu-west-1a", "eu-west-1b"}
+			client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+			deployer := NewDeployer(client, logr.Discard(), cfg, imageVec)
+
+			resources, err := deployer.generateResources()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := resources["deployment.yaml"]; ok {
+				t.Error("expected no deployment in the DaemonSet mode")
+			}
+
+			ds := &appsv1.DaemonSet{}
+			if err := runtime.DecodeInto(shootCodec, resources["daemonset.yaml"], ds); err != nil {
+				t.Fatalf("failed to decode daemonset: %v", err)
+			}
+			rollingUpdate := ds.Spec.UpdateStrategy.RollingUpdate
+			if rollingUpdate == nil || rollingUpdate.MaxUnavailable.IntValue() != 1 || rollingUpdate.MaxSurge.IntValue() != 0 {
+				t.Errorf("expected rolling update of one node at a time without surge, got: %v", ds.Spec.UpdateStrategy)
+			}
+
+			spec := ds.Spec.Template.Spec
+			if len(spec.TopologySpreadConstraints) != 0 {
+				t.Errorf("expected no topology spread constraints in the DaemonSet mode, got: %v", spec.TopologySpreadConstraints)
+			}
+			if spec.HostNetwork != tt.hostNetwork {
+				t.Errorf("expected host network: %t", tt.hostNetwork)
+			}
+			expectedDNSPolicy := corev1.DNSClusterFirst
+			if tt.hostNetwork {
+				expectedDNSPolicy = corev1.DNSClusterFirstWithHostNet
+			}
+			if spec.DNSPolicy != expectedDNSPolicy {
+				t.Errorf("expected DNS policy %s, got %s", expectedDNSPolicy, spec.DNSPolicy)
+			}
+			hostPorts := map[string]int32{}
+			for _, port := range spec.Containers[0].Ports {
+				hostPorts[port.Name] = port.HostPort
+			}
+			if tt.hostPort && (hostPorts["web"] != 80 || hostPorts["websecure"] != 443 || hostPorts["metrics"] != 0) {
+				t.Errorf("expected host ports 80 and 443 for the entrypoints, got: %v", hostPorts)
+			}
+			if !tt.hostPort && (hostPorts["web"] != 0 || hostPorts["websecure"] != 0) {
+				t.Errorf("expected no host ports, got: %v", hostPorts)
+			}
+
+			svc := &corev1.Service{}
+			if err := runtime.DecodeInto(shootCodec, resources["service.yaml"], svc); err != nil {
+				t.Fatalf("failed to decode service: %v", err)
+			}
+			if svc.Spec.Type != corev1.ServiceTypeNodePort {
+				t.Errorf("expected service of type NodePort, got %s", svc.Spec.Type)
+			}
+
+			pdb := &policyv1.PodDisruptionBudget{}
+			if err := runtime.DecodeInto(shootCodec, resources["poddisruptionbudget.yaml"], pdb); err != nil {
+				t.Fatalf("failed to decode pod disruption budget: %v", err)
+			}
+			if pdb.Spec.MinAvailable != nil || pdb.Spec.MaxUnavailable == nil || pdb.Spec.MaxUnavailable.IntValue() != 1 {
+				t.Errorf("expected at most one unavailable pod, got: %v", pdb.Spec)
+			}
+		})
+	}
+}
+
+func TestGenerateResources_DeploymentMode(t *testing.T) {
+	imageVec := imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	deployer := NewDeployer(client, logr.Discard(), DefaultConfig(), imageVec)
+
+	resources, err := deployer.generateResources()
 	if err != nil {
-		t.Fatalf("splitCRDs() error: %v", err)
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resources["daemonset.yaml"]; ok {
+		t.Error("expected no daemonset in the Deployment mode")
+	}
+	if _, ok := resources["deployment.yaml"]; !ok {
+		t.Error("expected a deployment in the Deployment mode")
 	}
 
-	expectedCRDs := []string{
-		"crd-ingressroutes.traefik.io.yaml",
-		"crd-ingressroutetcps.traefik.io.yaml",
-		"crd-ingressrouteudps.traefik.io.yaml",
-		"crd-middlewares.traefik.io.yaml",
-		"crd-middlewaretcps.traefik.io.yaml",
-		"crd-serverstransports.traefik.io.yaml",
-		"crd-serverstransporttcps.traefik.io.yaml",
-		"crd-tlsoptions.traefik.io.yaml",
-		"crd-tlsstores.traefik.io.yaml",
-		"crd-traefikservices.traefik.io.yaml",
+	svc := &corev1.Service{}
+	if err := runtime.DecodeInto(shootCodec, resources["service.yaml"], svc); err != nil {
+		t.Fatalf("failed to decode service: %v", err)
+	}
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		t.Errorf("expected service of type LoadBalancer, got %s", svc.Spec.Type)
 	}
 
-	if len(crds) != len(expectedCRDs) {
-		t.Errorf("expected %d CRDs, got %d", len(expectedCRDs), len(crds))
+	pdb := &policyv1.PodDisruptionBudget{}
+	if err := runtime.DecodeInto(shootCodec, resources["poddisruptionbudget.yaml"], pdb); err != nil {
+		t.Fatalf("failed to decode pod disruption budget: %v", err)
+	}
+	if pdb.Spec.MaxUnavailable != nil || pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.IntValue() != 1 {
+		t.Errorf("expected at least one available pod, got: %v", pdb.Spec)
 	}
+}
 
-	for _, name := range expectedCRDs {
-		data, ok := crds[name]
-		if !ok {
-			t.Errorf("expected CRD %q not found", name)
+func TestDeployment_GracefulShutdown(t *testing.T) {
+	imageVec := imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+	}
 
-			continue
-		}
-		if len(data) == 0 {
-			t.Errorf("CRD %q has empty data", name)
-		}
+	tests := []struct {
+		name                   string
+		modify                 func(cfg *Config)
+		expectMaxUnavailable   intstr.IntOrString
+		expectMaxSurge         intstr.IntOrString
+		expectShutdownDelay    string
+		expectTerminationTime  int64
+		expectProgressDeadline *int32
+	}{
+		{
+			name:                  "defaults",
+			expectMaxUnavailable:  intstr.FromInt32(0),
+			expectMaxSurge:        intstr.FromInt32(1),
+			expectShutdownDelay:   "15s",
+			expectTerminationTime: 30,
+		},
+		{
+			name: "custom rollout",
+			modify: func(cfg *Config) {
+				cfg.MaxUnavailable = intstr.FromString("25%")
+				cfg.MaxSurge = intstr.FromInt32(0)
+				cfg.ShutdownDelay = time.Minute
+				cfg.ProgressDeadline = 3 * time.Minute
+			},
+			expectMaxUnavailable:   intstr.FromString("25%"),
+			expectMaxSurge:         intstr.FromInt32(0),
+			expectShutdownDelay:    "1m0s",
+			expectTerminationTime:  75,
+			expectProgressDeadline: new(int32(180)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			if tt.modify != nil {
+				tt.modify(&cfg)
+			}
+			client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+			deployer := NewDeployer(client, logr.Discard(), cfg, imageVec)
+
+			deployment, err := deployer.deployment()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			strategy := deployment.Spec.Strategy
+			if strategy.Type != appsv1.RollingUpdateDeploymentStrategyType || strategy.RollingUpdate == nil {
+				t.Fatalf("expected rolling update strategy, got: %v", strategy)
+			}
+			if *strategy.RollingUpdate.MaxUnavailable != tt.expectMaxUnavailable || *strategy.RollingUpdate.MaxSurge != tt.expectMaxSurge {
+				t.Errorf("expected maxUnavailable %s and maxSurge %s, got %s and %s", tt.expectMaxUnavailable.String(), tt.expectMaxSurge.String(),
+					strategy.RollingUpdate.MaxUnavailable.String(), strategy.RollingUpdate.MaxSurge.String())
+			}
+			if progressDeadline := deployment.Spec.ProgressDeadlineSeconds; (progressDeadline == nil) != (tt.expectProgressDeadline == nil) ||
+				progressDeadline != nil && *progressDeadline != *tt.expectProgressDeadline {
+				t.Errorf("expected progress deadline %v, got: %v", tt.expectProgressDeadline, progressDeadline)
+			}
+
+			spec := deployment.Spec.Template.Spec
+			if spec.TerminationGracePeriodSeconds == nil || *spec.TerminationGracePeriodSeconds != tt.expectTerminationTime {
+				t.Errorf("expected termination grace period of %ds, got: %v", tt.expectTerminationTime, spec.TerminationGracePeriodSeconds)
+			}
+
+			args := spec.Containers[0].Args
+			for _, expectedArg := range []string{
+				"--ping.terminatingstatuscode=503",
+				"--entrypoints.web.transport.lifecycle.requestacceptgracetimeout=" + tt.expectShutdownDelay,
+				"--entrypoints.websecure.transport.lifecycle.requestacceptgracetimeout=" + tt.expectShutdownDelay,
+				"--entrypoints.web.transport.lifecycle.gracetimeout=10s",
+				"--entrypoints.websecure.transport.lifecycle.gracetimeout=10s",
+			} {
+				if !slices.Contains(args, expectedArg) {
+					t.Errorf("expected arg %q not found in deployment args: %v", expectedArg, args)
+				}
+			}
+		})
+	}
+}
+
+func TestDNSRecordDeployed(t *testing.T) {
+	const namespace = "shoot--foo--bar"
+
+	scheme := runtime.NewScheme()
+	_ = resourcesv1alpha1.AddToScheme(scheme)
+	_ = extensionsv1alpha1.AddToScheme(scheme)
+
+	meta := metav1.ObjectMeta{Namespace: namespace, Name: SeedManagedResourceName}
+	tests := []struct {
+		name     string
+		objects  []client.Object
+		expected bool
+	}{
+		{
+			name: "nothing deployed",
+		},
+		{
+			name:     "ManagedResource deployed",
+			objects:  []client.Object{&resourcesv1alpha1.ManagedResource{ObjectMeta: meta}},
+			expected: true,
+		},
+		{
+			name:     "DNSRecord left over",
+			objects:  []client.Object{&extensionsv1alpha1.DNSRecord{ObjectMeta: meta}},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			deployed, err := NewDeployer(c, logr.Discard(), DefaultConfig(), nil).DNSRecordDeployed(context.Background(), namespace)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if deployed != tt.expected {
+				t.Errorf("expected deployed %t, got: %t", tt.expected, deployed)
+			}
+		})
+	}
+}
+
+func TestDeployedDNSRecord(t *testing.T) {
+	const namespace = "shoot--foo--bar"
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = resourcesv1alpha1.AddToScheme(scheme)
+
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	deployer := NewDeployer(c, logr.Discard(), DefaultConfig(), nil)
+
+	dnsRecord, err := deployer.DeployedDNSRecord(ctx, namespace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dnsRecord != nil {
+		t.Fatalf("expected no DNSRecord, got: %v", dnsRecord)
+	}
+
+	secretRef := corev1.SecretReference{Name: "dns", Namespace: namespace}
+	if err := deployer.DeployDNSRecord(ctx, namespace, []string{"10.0.0.1"}, "*.ingress.foo.example.com", "aws-route53", secretRef); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dnsRecord, err = deployer.DeployedDNSRecord(ctx, namespace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dnsRecord == nil {
+		t.Fatal("expected DNSRecord to be deployed")
+	}
+	if dnsRecord.Spec.Name != "*.ingress.foo.example.com" || !slices.Equal(dnsRecord.Spec.Values, []string{"10.0.0.1"}) ||
+		dnsRecord.Spec.Type != "aws-route53" || dnsRecord.Spec.SecretRef != secretRef {
+		t.Errorf("unexpected DNSRecord spec: %+v", dnsRecord.Spec)
 	}
 }
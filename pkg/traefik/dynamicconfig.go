@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// dynamicConfigDirectory is the directory of the file provider, in
+	// which the ConfigMap with the dynamic configuration is mounted.
+	dynamicConfigDirectory = "/etc/traefik/dynamic"
+	// dynamicConfigVolumeName is the name of the volume of the ConfigMap
+	// with the dynamic configuration.
+	dynamicConfigVolumeName = "dynamic-config"
+)
+
+// ErrInvalidDynamicConfig is returned when the dynamic configuration of the
+// file provider cannot be parsed.
+var ErrInvalidDynamicConfig = errors.New("invalid traefik dynamic config")
+
+// ValidateDynamicConfig validates the syntax of the given dynamic
+// configuration files by their file name, i.e. the data of the ConfigMap of
+// the file provider. Files must have a ".yaml", ".yml" or ".toml" extension,
+// because Traefik ignores all other files. The errors of all files are
+// returned at once.
+func ValidateDynamicConfig(files map[string]string) error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if err := validateDynamicConfigFile(name, files[name]); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s: %w", ErrInvalidDynamicConfig, name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateDynamicConfigFile validates the syntax of the given dynamic
+// configuration file. The content must be a map at the top level, e.g. with
+// the "http" or "tls" sections.
+func validateDynamicConfigFile(name, content string) error {
+	cfg := map[string]any{}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal([]byte(content), &cfg); err != nil {
+			return err
+		}
+	case ".toml":
+		if _, err := toml.Decode(content, &cfg); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported file extension, must be one of .yaml, .yml or .toml")
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+func TestValidateDynamicConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		errorContains []string
+	}{
+		{
+			name: "valid YAML and TOML",
+			files: map[string]string{
+				"tls.yaml": "tls:\n  options:\n    default:\n      minVersion: VersionTLS12\n",
+				"tcp.yml":  "tcp:\n  routers:\n    db:\n      rule: HostSNI(`*`)\n      service: db\n",
+				"http.toml": `[http.services.external.loadBalancer]
+  [[http.services.external.loadBalancer.servers]]
+    url = "https://example.com"
+`,
+			},
+		},
+		{
+			name:  "empty ConfigMap",
+			files: map[string]string{},
+		},
+		{
+			name: "invalid YAML",
+			files: map[string]string{
+				"valid.yaml":   "http: {}\n",
+				"invalid.yaml": "http:\n  routers: [\n",
+			},
+			errorContains: []string{"invalid.yaml"},
+		},
+		{
+			name: "invalid TOML and unsupported extension",
+			files: map[string]string{
+				"invalid.toml": "[http\n",
+				"config.json":  "{}",
+			},
+			errorContains: []string{"invalid.toml", "config.json: unsupported file extension"},
+		},
+		{
+			name:          "YAML without a map",
+			files:         map[string]string{"list.yaml": "- http\n"},
+			errorContains: []string{"list.yaml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDynamicConfig(tt.files)
+			if len(tt.errorContains) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidDynamicConfig) {
+				t.Fatalf("expected ErrInvalidDynamicConfig, got: %v", err)
+			}
+			for _, expected := range tt.errorContains {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got: %v", expected, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDeployment_DynamicConfig(t *testing.T) {
+	imageVec := imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	deployer := NewDeployer(client, logr.Discard(), Config{
+		Replicas:             2,
+		IngressProvider:      config.IngressProviderKubernetesIngress,
+		DynamicConfigMapName: "my-dynamic-config",
+	}, imageVec)
+
+	deployment, err := deployer.deployment()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	podSpec := deployment.Spec.Template.Spec
+	container := podSpec.Containers[0]
+	for _, expectedArg := range []string{
+		"--providers.file.directory=/etc/traefik/dynamic",
+		"--providers.file.watch=true",
+	} {
+		if !slices.Contains(container.Args, expectedArg) {
+			t.Errorf("expected arg %q not found in deployment args: %v", expectedArg, container.Args)
+		}
+	}
+
+	if len(podSpec.Volumes) != 1 || podSpec.Volumes[0].ConfigMap == nil || podSpec.Volumes[0].ConfigMap.Name != "my-dynamic-config" {
+		t.Errorf("expected dynamic config volume, got: %v", podSpec.Volumes)
+	}
+	if len(container.VolumeMounts) != 1 || !container.VolumeMounts[0].ReadOnly || container.VolumeMounts[0].MountPath != "/etc/traefik/dynamic" {
+		t.Errorf("expected read-only dynamic config mount, got: %v", container.VolumeMounts)
+	}
+}
@@ -0,0 +1,502 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// errorPagesName is the name of the Deployment, Service, Middleware and
+	// IngressRoute of the default backend, and of the ConfigMap containing
+	// the error pages of the extension.
+	errorPagesName = "traefik-error-pages"
+	// errorPagesNginxName is the name of the ConfigMap containing the
+	// configuration of the error pages server.
+	errorPagesNginxName = "traefik-error-pages-nginx"
+	// errorPagesTLSName is the name of the IngressRoute of the default
+	// backend on the websecure entrypoint.
+	errorPagesTLSName = "traefik-error-pages-tls"
+	// errorPagesPort is the port of the error pages server.
+	errorPagesPort = 8080
+)
+
+// errorPageTemplate is the template of the error pages of the extension.
+//
+//go:embed errorpage.html.tmpl
+var errorPageTemplate string
+
+// errorPagesNginxConfig is the configuration of the error pages server. The
+// errors middleware requests "/<status code>.html", which falls back to
+// "/5xx.html" for 5xx status codes without a dedicated page. All other
+// requests are answered with the 404 page, because they are routed to the
+// default backend by the catch-all router.
+const errorPagesNginxConfig = `server {
+    listen 8080;
+    root /usr/share/nginx/html;
+
+    location = /healthz {
+        access_log off;
+        return 200;
+    }
+
+    location ~ "^/5[0-9]{2}\.html$" {
+        try_files $uri /5xx.html;
+    }
+
+    location / {
+        try_files $uri =404;
+    }
+
+    error_page 404 /404.html;
+}
+`
+
+// errorPages are the error pages of the extension.
+var errorPages = []struct {
+	file    string
+	code    string
+	title   string
+	message string
+}{
+	{"404.html", "404", "Not Found", "The requested page could not be found."},
+	{"5xx.html", "500", "Server Error", "The server encountered an error and could not complete your request."},
+	{"500.html", "500", "Internal Server Error", "The server encountered an error and could not complete your request."},
+	{"502.html", "502", "Bad Gateway", "The server received an invalid response from an upstream service."},
+	{"503.html", "503", "Service Unavailable", "The service is temporarily unavailable. Please try again later."},
+	{"504.html", "504", "Gateway Timeout", "The server did not receive a timely response from an upstream service."},
+}
+
+// renderErrorPages renders the error pages of the extension by their file
+// name.
+func renderErrorPages() (map[string]string, error) {
+	tmpl, err := template.New("errorpage").Parse(errorPageTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse error page template: %w", err)
+	}
+
+	pages := make(map[string]string, len(errorPages))
+	for _, page := range errorPages {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, map[string]string{
+			"Code":    page.code,
+			"Title":   page.title,
+			"Message": page.message,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to render error page %s: %w", page.file, err)
+		}
+		pages[page.file] = buf.String()
+	}
+
+	return pages, nil
+}
+
+// errorPagesResources returns the resources of the default backend by their
+// key in the ManagedResource secret. The ConfigMap with the error pages of
+// the extension is omitted, if the pages are taken from a ConfigMap of the
+// shoot owner.
+func (d *Deployer) errorPagesResources() (map[string][]byte, error) {
+	resources := make(map[string][]byte)
+
+	if d.config.errorPagesConfigMapName() == errorPagesName {
+		configMap, err := d.errorPagesConfigMap()
+		if err != nil {
+			return nil, err
+		}
+		configMapData, err := runtime.Encode(shootCodec, configMap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode error pages configmap: %w", err)
+		}
+		resources["error-pages-configmap.yaml"] = configMapData
+	}
+
+	nginxData, err := runtime.Encode(shootCodec, d.errorPagesNginxConfigMap())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode error pages server configmap: %w", err)
+	}
+	resources["error-pages-nginx-configmap.yaml"] = nginxData
+
+	deployment, err := d.errorPagesDeployment()
+	if err != nil {
+		return nil, err
+	}
+	deploymentData, err := runtime.Encode(shootCodec, deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode error pages deployment: %w", err)
+	}
+	resources["error-pages-deployment.yaml"] = deploymentData
+
+	serviceData, err := runtime.Encode(shootCodec, d.errorPagesService())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode error pages service: %w", err)
+	}
+	resources["error-pages-service.yaml"] = serviceData
+
+	networkPolicyData, err := runtime.Encode(shootCodec, d.errorPagesNetworkPolicy())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode error pages network policy: %w", err)
+	}
+	resources["error-pages-networkpolicy.yaml"] = networkPolicyData
+
+	middlewareData, err := runtime.Encode(unstructured.UnstructuredJSONScheme, d.errorPagesMiddleware())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode error pages middleware: %w", err)
+	}
+	resources["error-pages-middleware.yaml"] = middlewareData
+
+	for _, route := range d.errorPagesIngressRoutes() {
+		routeData, err := runtime.Encode(unstructured.UnstructuredJSONScheme, route)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode ingress route %s: %w", route.GetName(), err)
+		}
+		resources[fmt.Sprintf("error-pages-ingressroute-%s.yaml", route.GetName())] = routeData
+	}
+
+	return resources, nil
+}
+
+// errorPagesEnabled returns true, if the default backend serving error pages
+// is deployed.
+func (c Config) errorPagesEnabled() bool {
+	return c.ErrorPages != nil
+}
+
+// errorPagesConfigMapName returns the name of the ConfigMap, from which the
+// error pages are served.
+func (c Config) errorPagesConfigMapName() string {
+	if c.ErrorPages != nil && c.ErrorPages.ConfigMapName != "" {
+		return c.ErrorPages.ConfigMapName
+	}
+
+	return errorPagesName
+}
+
+// errorPagesLabels returns the labels of the error pages server.
+func errorPagesLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "traefik",
+		"app.kubernetes.io/instance":  errorPagesName,
+		"app.kubernetes.io/component": "error-pages",
+	}
+}
+
+// errorPagesConfigMap returns the ConfigMap containing the error pages of the
+// extension.
+func (d *Deployer) errorPagesConfigMap() (*corev1.ConfigMap, error) {
+	pages, err := renderErrorPages()
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      errorPagesName,
+			Namespace: Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "traefik",
+				"app.kubernetes.io/managed-by": "gardener",
+			},
+		},
+		Data: pages,
+	}, nil
+}
+
+// errorPagesNginxConfigMap returns the ConfigMap containing the configuration
+// of the error pages server.
+func (d *Deployer) errorPagesNginxConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      errorPagesNginxName,
+			Namespace: Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "traefik",
+				"app.kubernetes.io/managed-by": "gardener",
+			},
+		},
+		Data: map[string]string{
+			"default.conf": errorPagesNginxConfig,
+		},
+	}
+}
+
+// errorPagesDeployment returns the Deployment of the error pages server.
+func (d *Deployer) errorPagesDeployment() (*appsv1.Deployment, error) {
+	img, err := d.imageVector.FindImage(ErrorPagesImageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find error pages image in image vector: %w", err)
+	}
+
+	labels := errorPagesLabels()
+	labels["app.kubernetes.io/managed-by"] = "gardener"
+
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      errorPagesName,
+			Namespace: Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: new(int32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: errorPagesLabels(),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					AutomountServiceAccountToken: new(false),
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: new(true),
+						RunAsUser:    new(int64(101)),
+						RunAsGroup:   new(int64(101)),
+						FSGroup:      new(int64(101)),
+					},
+					Containers: []corev1.Container{
+						{
+							Name:  "error-pages",
+							Image: img.String(),
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "http",
+									ContainerPort: errorPagesPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path: "/healthz",
+										Port: intstr.FromInt32(errorPagesPort),
+									},
+								},
+								PeriodSeconds:    10,
+								TimeoutSeconds:   3,
+								FailureThreshold: 3,
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("10m"),
+									corev1.ResourceMemory: resource.MustParse("16Mi"),
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceMemory: resource.MustParse("64Mi"),
+								},
+							},
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: new(false),
+								ReadOnlyRootFilesystem:   new(true),
+								Capabilities: &corev1.Capabilities{
+									Drop: []corev1.Capability{"ALL"},
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "pages", MountPath: "/usr/share/nginx/html", ReadOnly: true},
+								{Name: "config", MountPath: "/etc/nginx/conf.d", ReadOnly: true},
+								{Name: "tmp", MountPath: "/tmp"},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "pages",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: d.config.errorPagesConfigMapName()},
+								},
+							},
+						},
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: errorPagesNginxName},
+								},
+							},
+						},
+						{
+							Name: "tmp",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// errorPagesService returns the Service of the error pages server.
+func (d *Deployer) errorPagesService() *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      errorPagesName,
+			Namespace: Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "traefik",
+				"app.kubernetes.io/managed-by": "gardener",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: errorPagesLabels(),
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromString("http"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
+// errorPagesNetworkPolicy returns the NetworkPolicy of the error pages
+// server, which only accepts traffic from Traefik and does not need any
+// egress traffic.
+func (d *Deployer) errorPagesNetworkPolicy() *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      errorPagesName,
+			Namespace: Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "traefik",
+				"app.kubernetes.io/managed-by": "gardener",
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: errorPagesLabels(),
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+				networkingv1.PolicyTypeEgress,
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"app.kubernetes.io/name":     "traefik",
+									"app.kubernetes.io/instance": "traefik",
+								},
+							},
+						},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						networkPolicyPort(intstr.FromInt32(errorPagesPort)),
+					},
+				},
+			},
+		},
+	}
+}
+
+// errorPagesEgressRule returns the egress rule, which allows Traefik to
+// reach the error pages server in the Restricted NetworkPolicy mode.
+func errorPagesEgressRule() networkingv1.NetworkPolicyEgressRule {
+	return networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						corev1.LabelMetadataName: Namespace,
+					},
+				},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: errorPagesLabels(),
+				},
+			},
+		},
+		Ports: []networkingv1.NetworkPolicyPort{
+			networkPolicyPort(intstr.FromInt32(errorPagesPort)),
+		},
+	}
+}
+
+// errorPagesMiddleware returns the errors Middleware, which replaces the
+// body of 5xx responses with the error pages.
+func (d *Deployer) errorPagesMiddleware() *unstructured.Unstructured {
+	return traefikObject("Middleware", errorPagesName, map[string]any{
+		"errors": map[string]any{
+			"status": []any{"500-599"},
+			"service": map[string]any{
+				"name": errorPagesName,
+				"port": int64(80),
+			},
+			"query": "/{status}.html",
+		},
+	})
+}
+
+// errorPagesIngressRoutes returns the catch-all IngressRoutes of the web and
+// websecure entrypoints, which route requests without a matching router to
+// the error pages server. They have the lowest priority, so that they never
+// take precedence over another router.
+func (d *Deployer) errorPagesIngressRoutes() []*unstructured.Unstructured {
+	route := func(name, entryPoint string) *unstructured.Unstructured {
+		spec := map[string]any{
+			"entryPoints": []any{entryPoint},
+			"routes": []any{
+				map[string]any{
+					"match":    "PathPrefix(`/`)",
+					"kind":     "Rule",
+					"priority": int64(1),
+					"services": []any{
+						map[string]any{
+							"name": errorPagesName,
+							"port": int64(80),
+						},
+					},
+				},
+			},
+		}
+		if entryPoint == "websecure" {
+			spec["tls"] = map[string]any{}
+		}
+
+		return traefikObject("IngressRoute", name, spec)
+	}
+
+	return []*unstructured.Unstructured{
+		route(errorPagesName, "web"),
+		route(errorPagesTLSName, "websecure"),
+	}
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+func errorPagesImageVector() imagevector.ImageVector {
+	return imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+		{
+			Name:       ErrorPagesImageName,
+			Repository: new("docker.io/nginxinc/nginx-unprivileged"),
+			Tag:        new("1.29-alpine"),
+		},
+	}
+}
+
+func TestRenderErrorPages(t *testing.T) {
+	pages, err := renderErrorPages()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for file, expected := range map[string]string{
+		"404.html": "Not Found",
+		"5xx.html": "Server Error",
+		"503.html": "Service Unavailable",
+	} {
+		page, ok := pages[file]
+		if !ok {
+			t.Errorf("expected error page %s to be rendered", file)
+
+			continue
+		}
+		if !strings.Contains(page, "<h2>"+expected+"</h2>") {
+			t.Errorf("expected error page %s to contain %q, got: %s", file, expected, page)
+		}
+	}
+}
+
+func TestGenerateResources_ErrorPages(t *testing.T) {
+	tests := []struct {
+		name              string
+		errorPages        *config.ErrorPages
+		expectConfigMap   string
+		expectGeneratedCM bool
+	}{
+		{
+			name:              "embedded error pages",
+			errorPages:        &config.ErrorPages{},
+			expectConfigMap:   "traefik-error-pages",
+			expectGeneratedCM: true,
+		},
+		{
+			name:            "error pages of the shoot owner",
+			errorPages:      &config.ErrorPages{ConfigMapName: "my-error-pages"},
+			expectConfigMap: "my-error-pages",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+			deployer := NewDeployer(client, logr.Discard(), Config{
+				Replicas:          2,
+				IngressProvider:   config.IngressProviderKubernetesIngress,
+				NetworkPolicyMode: config.NetworkPolicyModeRestricted,
+				ErrorPages:        tt.errorPages,
+			}, errorPagesImageVector())
+
+			resources, err := deployer.generateResources()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, ok := resources["error-pages-configmap.yaml"]; ok != tt.expectGeneratedCM {
+				t.Errorf("expected error pages configmap to be present: %t", tt.expectGeneratedCM)
+			}
+
+			deployment := &appsv1.Deployment{}
+			if err := runtime.DecodeInto(shootCodec, resources["error-pages-deployment.yaml"], deployment); err != nil {
+				t.Fatalf("failed to decode error pages deployment: %v", err)
+			}
+			if image := deployment.Spec.Template.Spec.Containers[0].Image; image != "docker.io/nginxinc/nginx-unprivileged:1.29-alpine" {
+				t.Errorf("unexpected error pages image: %s", image)
+			}
+			if name := deployment.Spec.Template.Spec.Volumes[0].ConfigMap.Name; name != tt.expectConfigMap {
+				t.Errorf("expected error pages from configmap %q, got %q", tt.expectConfigMap, name)
+			}
+
+			middleware := &unstructured.Unstructured{}
+			if err := middleware.UnmarshalJSON(resources["error-pages-middleware.yaml"]); err != nil {
+				t.Fatalf("failed to decode error pages middleware: %v", err)
+			}
+			if status, _, _ := unstructured.NestedStringSlice(middleware.Object, "spec", "errors", "status"); !slices.Equal(status, []string{"500-599"}) {
+				t.Errorf("unexpected status codes of the errors middleware: %v", status)
+			}
+
+			for key, expectTLS := range map[string]bool{
+				"error-pages-ingressroute-traefik-error-pages.yaml":     false,
+				"error-pages-ingressroute-traefik-error-pages-tls.yaml": true,
+			} {
+				route := &unstructured.Unstructured{}
+				if err := route.UnmarshalJSON(resources[key]); err != nil {
+					t.Fatalf("failed to decode %s: %v", key, err)
+				}
+				routes, _, _ := unstructured.NestedSlice(route.Object, "spec", "routes")
+				if len(routes) != 1 || routes[0].(map[string]any)["priority"] != int64(1) {
+					t.Errorf("expected a single catch-all route with the lowest priority, got: %v", routes)
+				}
+				if _, ok, _ := unstructured.NestedMap(route.Object, "spec", "tls"); ok != expectTLS {
+					t.Errorf("expected TLS of %s to be enabled: %t", key, expectTLS)
+				}
+			}
+
+			deploy, err := deployer.deployment()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := deploy.Spec.Template.Spec.Containers[0].Args
+			for _, expectedArg := range []string{
+				"--providers.kubernetescrd=true",
+				"--entrypoints.web.http.middlewares=kube-system-traefik-error-pages@kubernetescrd",
+				"--entrypoints.websecure.http.middlewares=kube-system-traefik-error-pages@kubernetescrd",
+			} {
+				if !slices.Contains(args, expectedArg) {
+					t.Errorf("expected arg %q not found in deployment args: %v", expectedArg, args)
+				}
+			}
+
+			networkPolicy := deployer.networkPolicy()
+			if !slices.ContainsFunc(networkPolicy.Spec.Egress, func(rule networkingv1.NetworkPolicyEgressRule) bool {
+				return len(rule.To) == 1 && rule.To[0].PodSelector != nil &&
+					rule.To[0].PodSelector.MatchLabels["app.kubernetes.io/instance"] == errorPagesName
+			}) {
+				t.Errorf("expected egress to the error pages server in the Restricted mode, got: %v", networkPolicy.Spec.Egress)
+			}
+		})
+	}
+}
+
+func TestGenerateResources_ErrorPagesImageMissing(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	deployer := NewDeployer(client, logr.Discard(), Config{
+		Replicas:        2,
+		IngressProvider: config.IngressProviderKubernetesIngress,
+		ErrorPages:      &config.ErrorPages{},
+	}, errorPagesImageVector()[:1])
+
+	if _, err := deployer.generateResources(); err == nil {
+		t.Error("expected an error without an error pages image")
+	}
+}
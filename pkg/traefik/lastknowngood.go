@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config/v1alpha1"
+)
+
+// lastKnownGoodSpecKey is the key of the provider config spec of the last
+// known good configuration of Traefik in the data of the
+// [LastKnownGoodSecretName] secret.
+const lastKnownGoodSpecKey = "spec.json"
+
+// SaveLastKnownGoodConfig stores the given provider config spec as the last
+// known good configuration of Traefik in a secret in the given namespace of the
+// seed cluster. A nil spec stands for a shoot without provider config.
+//
+// The spec is stored in its versioned form, so that it can be read by later
+// versions of the extension, and the configuration of Traefik is rebuilt from
+// it at rollback time. This way, the settings derived from the shoot, e.g. its
+// zones, backends and the credentials of the secure dashboard, are up to date
+// after a rollback, and the credentials are not stored in the secret.
+func SaveLastKnownGoodConfig(ctx context.Context, c client.Client, namespace string, spec *config.TraefikConfigSpec) error {
+	var versionedSpec *v1alpha1.TraefikConfigSpec
+	if spec != nil {
+		versionedSpec = &v1alpha1.TraefikConfigSpec{}
+		if err := v1alpha1.Convert_config_TraefikConfigSpec_To_v1alpha1_TraefikConfigSpec(spec, versionedSpec, nil); err != nil {
+			return fmt.Errorf("failed to convert last known good configuration: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(versionedSpec)
+	if err != nil {
+		return fmt.Errorf("failed to encode last known good configuration: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LastKnownGoodSecretName,
+			Namespace: namespace,
+		},
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(secret), secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get last known good configuration: %w", err)
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+	secret.Labels = map[string]string{
+		"app.kubernetes.io/name":       "traefik",
+		"app.kubernetes.io/managed-by": "gardener-extension-shoot-traefik",
+	}
+	secret.Type = corev1.SecretTypeOpaque
+	secret.Data = map[string][]byte{
+		lastKnownGoodSpecKey: data,
+	}
+
+	if secret.ResourceVersion == "" {
+		if err := c.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create last known good configuration: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := c.Patch(ctx, secret, patch); err != nil {
+		return fmt.Errorf("failed to update last known good configuration: %w", err)
+	}
+
+	return nil
+}
+
+// LastKnownGoodConfig returns the provider config spec of the last known good
+// configuration of Traefik, which is stored in the given namespace of the seed
+// cluster, and whether it was found. It is not found, if no configuration of
+// Traefik was rolled out successfully yet, or if the secret was stored by an
+// earlier version of the extension, which did not store the spec.
+func LastKnownGoodConfig(ctx context.Context, c client.Reader, namespace string) (*config.TraefikConfigSpec, bool, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: LastKnownGoodSecretName}, secret); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("failed to get last known good configuration: %w", err)
+	}
+
+	data, ok := secret.Data[lastKnownGoodSpecKey]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var versionedSpec *v1alpha1.TraefikConfigSpec
+	if err := json.Unmarshal(data, &versionedSpec); err != nil {
+		return nil, false, fmt.Errorf("failed to decode last known good configuration: %w", err)
+	}
+	if versionedSpec == nil {
+		return nil, true, nil
+	}
+
+	spec := &config.TraefikConfigSpec{}
+	if err := v1alpha1.Convert_v1alpha1_TraefikConfigSpec_To_config_TraefikConfigSpec(versionedSpec, spec, nil); err != nil {
+		return nil, false, fmt.Errorf("failed to convert last known good configuration: %w", err)
+	}
+
+	return spec, true, nil
+}
+
+// DeleteLastKnownGoodConfig deletes the last known good configuration of
+// Traefik from the given namespace of the seed cluster.
+func DeleteLastKnownGoodConfig(ctx context.Context, c client.Client, namespace string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LastKnownGoodSecretName,
+			Namespace: namespace,
+		},
+	}
+	if err := c.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete last known good configuration: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+func TestLastKnownGoodConfig(t *testing.T) {
+	const namespace = "shoot--foo--bar"
+
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+
+	spec, found, err := LastKnownGoodConfig(ctx, c, namespace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found || spec != nil {
+		t.Fatalf("expected no last known good configuration, got: %v", spec)
+	}
+
+	tests := []struct {
+		name string
+		spec *config.TraefikConfigSpec
+	}{
+		{
+			name: "spec",
+			spec: &config.TraefikConfigSpec{
+				Replicas:      2,
+				Namespaces:    []string{"app-a"},
+				LabelSelector: "ingress=true",
+				DefaultMiddlewares: &config.DefaultMiddlewares{
+					IPAllowList: &config.IPAllowList{SourceRange: []string{"10.0.0.0/8"}},
+				},
+				Scheduling: &config.Scheduling{
+					Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+				},
+			},
+		},
+		{
+			name: "shoot without provider config",
+			spec: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SaveLastKnownGoodConfig(ctx, c, namespace, tt.spec); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			spec, found, err := LastKnownGoodConfig(ctx, c, namespace)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !found || !apiequality.Semantic.DeepEqual(spec, tt.spec) {
+				t.Errorf("expected last known good configuration %+v, got: %+v", tt.spec, spec)
+			}
+		})
+	}
+
+	// The complete configuration of Traefik, which was stored by earlier
+	// versions of the extension, is ignored.
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: LastKnownGoodSecretName}, secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	secret.Data = map[string][]byte{"config.json": []byte(`{"Replicas":2}`)}
+	if err := c.Update(ctx, secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec, found, err := LastKnownGoodConfig(ctx, c, namespace); err != nil || found {
+		t.Errorf("expected the configuration of an earlier version to be ignored, got: %v, %v", spec, err)
+	}
+
+	if err := DeleteLastKnownGoodConfig(ctx, c, namespace); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec, found, err := LastKnownGoodConfig(ctx, c, namespace); err != nil || found {
+		t.Errorf("expected last known good configuration to be deleted, got: %v, %v", spec, err)
+	}
+	if err := DeleteLastKnownGoodConfig(ctx, c, namespace); err != nil {
+		t.Errorf("expected deleting a missing configuration to succeed, got: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// ipAllowListMiddlewareName is the name of the default IP allowlist
+	// middleware.
+	ipAllowListMiddlewareName = "traefik-default-ip-allowlist"
+	// rateLimitMiddlewareName is the name of the default rate limit
+	// middleware.
+	rateLimitMiddlewareName = "traefik-default-rate-limit"
+	// bufferingMiddlewareName is the name of the default buffering
+	// middleware, which limits the size of request bodies.
+	bufferingMiddlewareName = "traefik-default-buffering"
+	// securityHeadersMiddlewareName is the name of the default security
+	// headers middleware.
+	securityHeadersMiddlewareName = "traefik-default-security-headers"
+
+	// defaultSTSSeconds is the default max-age of the
+	// Strict-Transport-Security header.
+	defaultSTSSeconds int64 = 31536000
+	// defaultReferrerPolicy is the default value of the Referrer-Policy
+	// header.
+	defaultReferrerPolicy = "strict-origin-when-cross-origin"
+	// defaultRateLimitPeriod is the default period of the rate limit.
+	defaultRateLimitPeriod = time.Second
+)
+
+// defaultMiddlewaresEnabled returns true, if any default middleware is
+// configured for the web and websecure entrypoints.
+func (c Config) defaultMiddlewaresEnabled() bool {
+	m := c.DefaultMiddlewares
+	if m == nil {
+		return false
+	}
+
+	return m.IPAllowList != nil || m.RateLimit != nil || m.MaxRequestBodyBytes != nil || m.SecurityHeaders != nil
+}
+
+// clientIPMiddlewaresEnabled returns true, if a default middleware acts on
+// the client IP of requests, which must therefore be preserved by the Service
+// of Traefik.
+func (c Config) clientIPMiddlewaresEnabled() bool {
+	m := c.DefaultMiddlewares
+	if m == nil {
+		return false
+	}
+
+	return m.IPAllowList != nil || m.RateLimit != nil
+}
+
+// defaultMiddlewares returns the configured default middlewares in the order,
+// in which they are applied to requests. Requests from disallowed sources are
+// rejected first, before they count against the rate limit or are buffered.
+func (d *Deployer) defaultMiddlewares() []*unstructured.Unstructured {
+	m := d.config.DefaultMiddlewares
+	if m == nil {
+		return nil
+	}
+
+	middlewares := []*unstructured.Unstructured{}
+	if m.IPAllowList != nil {
+		sourceRange := make([]any, 0, len(m.IPAllowList.SourceRange))
+		for _, r := range m.IPAllowList.SourceRange {
+			sourceRange = append(sourceRange, r)
+		}
+		middlewares = append(middlewares, traefikObject("Middleware", ipAllowListMiddlewareName, map[string]any{
+			"ipAllowList": map[string]any{
+				"sourceRange": sourceRange,
+			},
+		}))
+	}
+
+	if m.RateLimit != nil {
+		burst := m.RateLimit.Average
+		if m.RateLimit.Burst != nil {
+			burst = *m.RateLimit.Burst
+		}
+		period := defaultRateLimitPeriod
+		if m.RateLimit.Period != nil {
+			period = m.RateLimit.Period.Duration
+		}
+		middlewares = append(middlewares, traefikObject("Middleware", rateLimitMiddlewareName, map[string]any{
+			"rateLimit": map[string]any{
+				"average": m.RateLimit.Average,
+				"burst":   burst,
+				"period":  period.String(),
+			},
+		}))
+	}
+
+	if m.MaxRequestBodyBytes != nil {
+		middlewares = append(middlewares, traefikObject("Middleware", bufferingMiddlewareName, map[string]any{
+			"buffering": map[string]any{
+				"maxRequestBodyBytes": *m.MaxRequestBodyBytes,
+			},
+		}))
+	}
+
+	if h := m.SecurityHeaders; h != nil {
+		stsSeconds := defaultSTSSeconds
+		if h.STSSeconds != nil {
+			stsSeconds = *h.STSSeconds
+		}
+		middlewares = append(middlewares, traefikObject("Middleware", securityHeadersMiddlewareName, map[string]any{
+			"headers": map[string]any{
+				"stsSeconds":           stsSeconds,
+				"stsIncludeSubdomains": h.STSIncludeSubdomains,
+				"stsPreload":           h.STSPreload,
+				"frameDeny":            true,
+				"contentTypeNosniff":   true,
+				"referrerPolicy":       cmp.Or(h.ReferrerPolicy, defaultReferrerPolicy),
+			},
+		}))
+	}
+
+	return middlewares
+}
+
+// entryPointMiddlewares returns the references of the default middlewares
+// and of the errors middleware of the error pages for the entrypoint
+// configuration. Middlewares of the Kubernetes CRD provider are referenced as
+// "<namespace>-<name>@kubernetescrd".
+func (d *Deployer) entryPointMiddlewares() string {
+	middlewares := d.defaultMiddlewares()
+	if d.config.errorPagesEnabled() {
+		middlewares = append(middlewares, d.errorPagesMiddleware())
+	}
+	refs := make([]string, 0, len(middlewares))
+	for _, middleware := range middlewares {
+		refs = append(refs, fmt.Sprintf("%s-%s@kubernetescrd", middleware.GetNamespace(), middleware.GetName()))
+	}
+
+	return strings.Join(refs, ",")
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+func TestDeployment_DefaultMiddlewares(t *testing.T) {
+	imageVec := imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+	}
+
+	tests := []struct {
+		name               string
+		middlewares        *config.DefaultMiddlewares
+		expectMiddlewares  string
+		expectPingPort     int
+		expectCRDProvider  bool
+		unexpectedArgument string
+	}{
+		{
+			name:               "no default middlewares",
+			expectPingPort:     8000,
+			unexpectedArgument: "--entrypoints.web.http.middlewares=",
+		},
+		{
+			name:               "empty default middlewares",
+			middlewares:        &config.DefaultMiddlewares{},
+			expectPingPort:     8000,
+			unexpectedArgument: "--entrypoints.web.http.middlewares=",
+		},
+		{
+			name: "all default middlewares",
+			middlewares: &config.DefaultMiddlewares{
+				SecurityHeaders:     &config.SecurityHeaders{},
+				RateLimit:           &config.RateLimit{Average: 100},
+				IPAllowList:         &config.IPAllowList{SourceRange: []string{"10.0.0.0/8"}},
+				MaxRequestBodyBytes: new(int64(1024)),
+			},
+			expectMiddlewares: "kube-system-traefik-default-ip-allowlist@kubernetescrd," +
+				"kube-system-traefik-default-rate-limit@kubernetescrd," +
+				"kube-system-traefik-default-buffering@kubernetescrd," +
+				"kube-system-traefik-default-security-headers@kubernetescrd",
+			expectPingPort:    9100,
+			expectCRDProvider: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+			deployer := NewDeployer(client, logr.Discard(), Config{
+				Replicas:           2,
+				IngressProvider:    config.IngressProviderKubernetesIngress,
+				DefaultMiddlewares: tt.middlewares,
+			}, imageVec)
+
+			deployment, err := deployer.deployment()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			container := deployment.Spec.Template.Spec.Containers[0]
+			if tt.expectMiddlewares != "" {
+				for _, expectedArg := range []string{
+					"--entrypoints.web.http.middlewares=" + tt.expectMiddlewares,
+					"--entrypoints.websecure.http.middlewares=" + tt.expectMiddlewares,
+					"--ping.entrypoint=metrics",
+				} {
+					if !slices.Contains(container.Args, expectedArg) {
+						t.Errorf("expected arg %q not found in deployment args: %v", expectedArg, container.Args)
+					}
+				}
+			}
+			if tt.unexpectedArgument != "" {
+				for _, arg := range container.Args {
+					if strings.HasPrefix(arg, tt.unexpectedArgument) {
+						t.Errorf("unexpected arg %q in deployment args", arg)
+					}
+				}
+			}
+			if slices.Contains(container.Args, "--providers.kubernetescrd=true") != tt.expectCRDProvider {
+				t.Errorf("expected kubernetes CRD provider to be enabled: %t, got args: %v", tt.expectCRDProvider, container.Args)
+			}
+
+			for _, probe := range []struct {
+				name string
+				port int
+			}{
+				{"startup", container.StartupProbe.HTTPGet.Port.IntValue()},
+				{"liveness", container.LivenessProbe.HTTPGet.Port.IntValue()},
+				{"readiness", container.ReadinessProbe.HTTPGet.Port.IntValue()},
+			} {
+				if probe.port != tt.expectPingPort {
+					t.Errorf("expected %s probe on port %d, got %d", probe.name, tt.expectPingPort, probe.port)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateResources_DefaultMiddlewares(t *testing.T) {
+	imageVec := imagevector.ImageVector{
+		{
+			Name:       "traefik",
+			Repository: new("docker.io/library/traefik"),
+			Tag:        new("v3.6.10"),
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	deployer := NewDeployer(client, logr.Discard(), Config{
+		Replicas:        2,
+		IngressProvider: config.IngressProviderKubernetesIngress,
+		Namespaces:      []string{"app-a"},
+		DefaultMiddlewares: &config.DefaultMiddlewares{
+			SecurityHeaders: &config.SecurityHeaders{
+				STSIncludeSubdomains: true,
+			},
+			RateLimit: &config.RateLimit{
+				Average: 100,
+				Period:  &metav1.Duration{Duration: time.Minute},
+			},
+			IPAllowList: &config.IPAllowList{SourceRange: []string{"10.0.0.0/8", "192.168.1.1"}},
+		},
+	}, imageVec)
+
+	resources, err := deployer.generateResources()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := resources["middleware-traefik-default-buffering.yaml"]; ok {
+		t.Error("expected no buffering middleware without a maximum request body size")
+	}
+	if _, ok := resources["role-kube-system.yaml"]; !ok {
+		t.Error("expected secret access in kube-system for the kubernetes CRD provider")
+	}
+
+	decode := func(key string) map[string]any {
+		t.Helper()
+
+		middleware := &unstructured.Unstructured{}
+		if err := middleware.UnmarshalJSON(resources[key]); err != nil {
+			t.Fatalf("failed to decode %s: %v", key, err)
+		}
+		if middleware.GetKind() != "Middleware" || middleware.GetNamespace() != Namespace {
+			t.Errorf("unexpected middleware %s/%s of kind %s", middleware.GetNamespace(), middleware.GetName(), middleware.GetKind())
+		}
+		spec, _, _ := unstructured.NestedMap(middleware.Object, "spec")
+
+		return spec
+	}
+
+	headers, _, _ := unstructured.NestedMap(decode("middleware-traefik-default-security-headers.yaml"), "headers")
+	if headers["stsSeconds"] != int64(31536000) || headers["stsIncludeSubdomains"] != true || headers["stsPreload"] != false {
+		t.Errorf("unexpected HSTS settings: %v", headers)
+	}
+	if headers["referrerPolicy"] != "strict-origin-when-cross-origin" || headers["frameDeny"] != true || headers["contentTypeNosniff"] != true {
+		t.Errorf("unexpected security headers: %v", headers)
+	}
+
+	rateLimit, _, _ := unstructured.NestedMap(decode("middleware-traefik-default-rate-limit.yaml"), "rateLimit")
+	if rateLimit["average"] != int64(100) || rateLimit["burst"] != int64(100) || rateLimit["period"] != "1m0s" {
+		t.Errorf("unexpected rate limit: %v", rateLimit)
+	}
+
+	sourceRange, _, _ := unstructured.NestedStringSlice(decode("middleware-traefik-default-ip-allowlist.yaml"), "ipAllowList", "sourceRange")
+	if !slices.Equal(sourceRange, []string{"10.0.0.0/8", "192.168.1.1"}) {
+		t.Errorf("unexpected IP allowlist: %v", sourceRange)
+	}
+}
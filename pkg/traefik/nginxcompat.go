@@ -0,0 +1,282 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils/managedresources"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+// NginxAnnotationPrefix is the prefix of the annotations of the Ingress
+// resources, which configure the nginx-ingress-controller.
+const NginxAnnotationPrefix = "nginx.ingress.kubernetes.io/"
+
+// nginxCompatibilityReportKey is the key of the report in the data of the
+// nginx annotation compatibility ConfigMap.
+const nginxCompatibilityReportKey = "report.yaml"
+
+// nginxCompatibilityYAML contains the support of the nginx annotations by the
+// KubernetesIngressNGINX provider of Traefik. The first line of the file
+// references the documentation, from which the support was taken.
+//
+//go:embed nginxcompat.yaml
+var nginxCompatibilityYAML []byte
+
+// AnnotationSupport is the support of an nginx annotation by Traefik.
+type AnnotationSupport string
+
+const (
+	// AnnotationSupported is the [AnnotationSupport] of an annotation, which
+	// Traefik handles like the nginx-ingress-controller.
+	AnnotationSupported AnnotationSupport = "Supported"
+	// AnnotationPartiallySupported is the [AnnotationSupport] of an
+	// annotation, which Traefik handles with limitations.
+	AnnotationPartiallySupported AnnotationSupport = "PartiallySupported"
+	// AnnotationUnsupported is the [AnnotationSupport] of an annotation, which
+	// Traefik ignores.
+	AnnotationUnsupported AnnotationSupport = "Unsupported"
+)
+
+// AnnotationCompatibility is the support of an nginx annotation by Traefik.
+type AnnotationCompatibility struct {
+	// Annotation is the name of the annotation including its prefix.
+	Annotation string `json:"annotation"`
+	// Support is the support of the annotation.
+	Support AnnotationSupport `json:"support"`
+	// Note describes the limitations or the alternatives of the annotation.
+	Note string `json:"note,omitempty"`
+}
+
+// IngressCompatibility are the nginx annotations of an Ingress, which Traefik
+// does not fully support.
+type IngressCompatibility struct {
+	// Namespace is the namespace of the Ingress.
+	Namespace string `json:"namespace"`
+	// Name is the name of the Ingress.
+	Name string `json:"name"`
+	// Annotations are the partially supported and unsupported annotations of
+	// the Ingress sorted by their name.
+	Annotations []AnnotationCompatibility `json:"annotations"`
+}
+
+// NginxCompatibilityReport is the support of the nginx annotations of the
+// Ingress resources of the nginx ingress class by Traefik.
+type NginxCompatibilityReport struct {
+	// Ingresses is the number of analyzed Ingress resources.
+	Ingresses int `json:"ingresses"`
+	// Supported is the number of supported annotations.
+	Supported int `json:"supported"`
+	// PartiallySupported is the number of partially supported annotations.
+	PartiallySupported int `json:"partiallySupported"`
+	// Unsupported is the number of unsupported annotations.
+	Unsupported int `json:"unsupported"`
+	// Incompatible are the Ingress resources with partially supported or
+	// unsupported annotations sorted by their namespace and name.
+	Incompatible []IngressCompatibility `json:"incompatible,omitempty"`
+}
+
+// nginxCompatibilityTable returns the support of the nginx annotations, which
+// is parsed from the embedded compatibility table, keyed by the name of the
+// annotation including its prefix.
+var nginxCompatibilityTable = sync.OnceValues(func() (map[string]AnnotationCompatibility, error) {
+	entries := []struct {
+		Name    string            `json:"name"`
+		Support AnnotationSupport `json:"support"`
+		Note    string            `json:"note,omitempty"`
+	}{}
+	if err := yaml.UnmarshalStrict(nginxCompatibilityYAML, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse nginx compatibility table: %w", err)
+	}
+
+	table := make(map[string]AnnotationCompatibility, len(entries))
+	for _, entry := range entries {
+		annotation := NginxAnnotationPrefix + entry.Name
+		if _, ok := table[annotation]; ok {
+			return nil, fmt.Errorf("duplicate annotation %s in nginx compatibility table", annotation)
+		}
+
+		switch entry.Support {
+		case AnnotationSupported, AnnotationPartiallySupported, AnnotationUnsupported:
+		default:
+			return nil, fmt.Errorf("invalid support %q of annotation %s in nginx compatibility table", entry.Support, annotation)
+		}
+
+		table[annotation] = AnnotationCompatibility{Annotation: annotation, Support: entry.Support, Note: entry.Note}
+	}
+
+	return table, nil
+})
+
+// ClassifyNginxAnnotation returns the support of the given nginx annotation by
+// Traefik. Annotations, which are not contained in the compatibility table,
+// are unsupported.
+func ClassifyNginxAnnotation(annotation string) (AnnotationCompatibility, error) {
+	table, err := nginxCompatibilityTable()
+	if err != nil {
+		return AnnotationCompatibility{}, err
+	}
+
+	compatibility, ok := table[annotation]
+	if !ok {
+		return AnnotationCompatibility{Annotation: annotation, Support: AnnotationUnsupported, Note: "unknown annotation"}, nil
+	}
+
+	return compatibility, nil
+}
+
+// AnalyzeNginxIngresses returns the support of the nginx annotations of the
+// given Ingress resources by Traefik.
+func AnalyzeNginxIngresses(ingresses []networkingv1.Ingress) (*NginxCompatibilityReport, error) {
+	report := &NginxCompatibilityReport{Ingresses: len(ingresses)}
+	for _, ing := range ingresses {
+		incompatible := IngressCompatibility{Namespace: ing.Namespace, Name: ing.Name}
+		for _, annotation := range slices.Sorted(maps.Keys(ing.Annotations)) {
+			if !strings.HasPrefix(annotation, NginxAnnotationPrefix) {
+				continue
+			}
+
+			compatibility, err := ClassifyNginxAnnotation(annotation)
+			if err != nil {
+				return nil, err
+			}
+
+			switch compatibility.Support {
+			case AnnotationSupported:
+				report.Supported++
+
+				continue
+			case AnnotationPartiallySupported:
+				report.PartiallySupported++
+			case AnnotationUnsupported:
+				report.Unsupported++
+			}
+			incompatible.Annotations = append(incompatible.Annotations, compatibility)
+		}
+
+		if len(incompatible.Annotations) > 0 {
+			report.Incompatible = append(report.Incompatible, incompatible)
+		}
+	}
+
+	slices.SortFunc(report.Incompatible, func(a, b IngressCompatibility) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+
+	return report, nil
+}
+
+// AnalyzeNginxCompatibility returns the support of the nginx annotations of
+// the Ingress resources of the nginx ingress class by Traefik. Only the
+// Ingress resources in the namespaces and with the labels of the given config
+// are analyzed.
+func AnalyzeNginxCompatibility(ctx context.Context, c client.Reader, cfg Config) (*NginxCompatibilityReport, error) {
+	cfg.IngressProvider = config.IngressProviderKubernetesIngressNGINX
+	ingresses, err := listIngresses(ctx, c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return AnalyzeNginxIngresses(ingresses)
+}
+
+// NginxCompatibilityResources returns the resources of the ManagedResource,
+// which [Deployer.DeployNginxCompatibilityReport] deploys for the given
+// report, keyed by their name in the ManagedResource secret.
+func (d *Deployer) NginxCompatibilityResources(report *NginxCompatibilityReport) (map[string][]byte, error) {
+	reportData, err := yaml.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode nginx compatibility report: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NginxCompatibilityConfigMapName,
+			Namespace: Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "traefik",
+				"app.kubernetes.io/instance":   "traefik",
+				"app.kubernetes.io/managed-by": "gardener",
+			},
+		},
+		Data: map[string]string{nginxCompatibilityReportKey: string(reportData)},
+	}
+
+	configMapData, err := runtime.Encode(shootCodec, configMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode nginx compatibility ConfigMap: %w", err)
+	}
+
+	return map[string][]byte{"nginx-compatibility-configmap.yaml": configMapData}, nil
+}
+
+// DeployNginxCompatibilityReport creates or updates the ManagedResource, which
+// publishes the given nginx annotation compatibility report as ConfigMap in
+// the shoot cluster.
+func (d *Deployer) DeployNginxCompatibilityReport(ctx context.Context, namespace string, report *NginxCompatibilityReport) error {
+	resources, err := d.NginxCompatibilityResources(report)
+	if err != nil {
+		return err
+	}
+
+	if err := managedresources.CreateForShoot(ctx, d.client, namespace, NginxCompatibilityManagedResourceName, ManagedResourceName, false, resources); err != nil {
+		return fmt.Errorf("failed to create or update nginx compatibility managed resource: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteNginxCompatibilityReport removes the ManagedResource of the nginx
+// annotation compatibility report, if it exists. The ConfigMap is kept in the
+// shoot cluster, if keepObjects is true, e.g. during force-delete or migrate.
+func (d *Deployer) DeleteNginxCompatibilityReport(ctx context.Context, namespace string, keepObjects bool) error {
+	mr := &resourcesv1alpha1.ManagedResource{}
+	if err := d.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: NginxCompatibilityManagedResourceName}, mr); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get nginx compatibility managed resource: %w", err)
+	}
+
+	if keepObjects {
+		if err := managedresources.SetKeepObjects(ctx, d.client, namespace, NginxCompatibilityManagedResourceName, true); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to set keepObjects on nginx compatibility managed resource: %w", err)
+		}
+	}
+
+	if err := managedresources.Delete(ctx, d.client, namespace, NginxCompatibilityManagedResourceName, true); err != nil {
+		return fmt.Errorf("failed to delete nginx compatibility managed resource: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, ManagedResourceDeletionTimeout)
+	defer cancel()
+
+	if err := managedresources.WaitUntilDeleted(timeoutCtx, d.client, namespace, NginxCompatibilityManagedResourceName); err != nil {
+		return fmt.Errorf("timed out waiting for nginx compatibility managed resource to be deleted: %w", err)
+	}
+
+	return nil
+}
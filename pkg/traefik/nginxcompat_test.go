@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func nginxIngress(namespace, name string, class *string, annotations map[string]string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Annotations: annotations},
+		Spec:       networkingv1.IngressSpec{IngressClassName: class},
+	}
+}
+
+func TestClassifyNginxAnnotation(t *testing.T) {
+	tests := []struct {
+		annotation string
+		expected   AnnotationSupport
+	}{
+		{annotation: "nginx.ingress.kubernetes.io/ssl-redirect", expected: AnnotationSupported},
+		{annotation: "nginx.ingress.kubernetes.io/backend-protocol", expected: AnnotationPartiallySupported},
+		{annotation: "nginx.ingress.kubernetes.io/configuration-snippet", expected: AnnotationUnsupported},
+		{annotation: "nginx.ingress.kubernetes.io/does-not-exist", expected: AnnotationUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.annotation, func(t *testing.T) {
+			compatibility, err := ClassifyNginxAnnotation(tt.annotation)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if compatibility.Annotation != tt.annotation || compatibility.Support != tt.expected {
+				t.Errorf("expected %s to be %s, got: %+v", tt.annotation, tt.expected, compatibility)
+			}
+		})
+	}
+}
+
+func TestNginxCompatibilityTable(t *testing.T) {
+	table, err := nginxCompatibilityTable()
+	if err != nil {
+		t.Fatalf("failed to parse nginx compatibility table: %v", err)
+	}
+	if len(table) == 0 {
+		t.Fatal("expected nginx compatibility table to contain annotations")
+	}
+
+	for annotation, compatibility := range table {
+		if compatibility.Support == AnnotationPartiallySupported && compatibility.Note == "" {
+			t.Errorf("expected a note describing the limitations of %s", annotation)
+		}
+		if strings.Count(annotation, "/") != 1 {
+			t.Errorf("expected the name of %s to omit the annotation prefix", annotation)
+		}
+	}
+}
+
+func TestAnalyzeNginxCompatibility(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	objects := []client.Object{
+		nginxIngress("b", "shop", new("nginx"), map[string]string{
+			"nginx.ingress.kubernetes.io/ssl-redirect":          "true",
+			"nginx.ingress.kubernetes.io/configuration-snippet": "more_set_headers \"X-Foo: bar\";",
+			"nginx.ingress.kubernetes.io/affinity-mode":         "persistent",
+			"cert.gardener.cloud/purpose":                       "managed",
+		}),
+		nginxIngress("a", "legacy", nil, map[string]string{
+			ingressClassAnnotation:                    "nginx",
+			"nginx.ingress.kubernetes.io/limit-rps":   "10",
+			"nginx.ingress.kubernetes.io/use-regex":   "true",
+			"nginx.ingress.kubernetes.io/enable-cors": "true",
+		}),
+		nginxIngress("a", "compatible", new("nginx"), map[string]string{
+			"nginx.ingress.kubernetes.io/rewrite-target": "/",
+		}),
+		nginxIngress("a", "other-class", new("traefik"), map[string]string{
+			"nginx.ingress.kubernetes.io/server-snippet": "",
+		}),
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	report, err := AnalyzeNginxCompatibility(context.Background(), c, DefaultConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	limitRPS, _ := ClassifyNginxAnnotation("nginx.ingress.kubernetes.io/limit-rps")
+	affinityMode, _ := ClassifyNginxAnnotation("nginx.ingress.kubernetes.io/affinity-mode")
+	snippet, _ := ClassifyNginxAnnotation("nginx.ingress.kubernetes.io/configuration-snippet")
+	expected := &NginxCompatibilityReport{
+		Ingresses:          3,
+		Supported:          4,
+		PartiallySupported: 1,
+		Unsupported:        2,
+		Incompatible: []IngressCompatibility{
+			{Namespace: "a", Name: "legacy", Annotations: []AnnotationCompatibility{limitRPS}},
+			{Namespace: "b", Name: "shop", Annotations: []AnnotationCompatibility{affinityMode, snippet}},
+		},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("expected report %+v, got: %+v", expected, report)
+	}
+}
+
+func TestNginxCompatibilityReport_DeployAndDelete(t *testing.T) {
+	const namespace = "shoot--foo--bar"
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = resourcesv1alpha1.AddToScheme(scheme)
+
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	deployer := NewDeployer(c, logr.Discard(), DefaultConfig(), nil)
+
+	report := &NginxCompatibilityReport{
+		Ingresses:   1,
+		Unsupported: 1,
+		Incompatible: []IngressCompatibility{{
+			Namespace:   "default",
+			Name:        "shop",
+			Annotations: []AnnotationCompatibility{{Annotation: "nginx.ingress.kubernetes.io/limit-rps", Support: AnnotationUnsupported}},
+		}},
+	}
+	if err := deployer.DeployNginxCompatibilityReport(ctx, namespace, report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mr := &resourcesv1alpha1.ManagedResource{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: NginxCompatibilityManagedResourceName}, mr); err != nil {
+		t.Fatalf("failed to get managed resource: %v", err)
+	}
+	if len(mr.Spec.SecretRefs) != 1 {
+		t.Fatalf("expected one secret, got: %v", mr.Spec.SecretRefs)
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: mr.Spec.SecretRefs[0].Name}, secret); err != nil {
+		t.Fatalf("failed to get managed resource secret: %v", err)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := yaml.Unmarshal(secret.Data["nginx-compatibility-configmap.yaml"], configMap); err != nil {
+		t.Fatalf("failed to decode ConfigMap: %v", err)
+	}
+	if configMap.Namespace != Namespace || configMap.Name != NginxCompatibilityConfigMapName {
+		t.Errorf("expected ConfigMap %s/%s, got: %s/%s", Namespace, NginxCompatibilityConfigMapName, configMap.Namespace, configMap.Name)
+	}
+	decoded := &NginxCompatibilityReport{}
+	if err := yaml.Unmarshal([]byte(configMap.Data[nginxCompatibilityReportKey]), decoded); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, report) {
+		t.Errorf("expected report %+v, got: %+v", report, decoded)
+	}
+
+	for range 2 {
+		if err := deployer.DeleteNginxCompatibilityReport(ctx, namespace, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(mr), mr); !apierrors.IsNotFound(err) {
+		t.Errorf("expected managed resource to be deleted, got: %v", err)
+	}
+}
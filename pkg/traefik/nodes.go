@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DiscoverNodeAddresses returns the external IPs of the nodes, which run a
+// running Traefik pod in the DaemonSet mode. The internal IPs of these nodes
+// are returned instead, if none of them has an external IP, which is common
+// for local and bare metal setups. The addresses are sorted and of a single
+// IP family, because a DNS record has a single record type. IPv4 addresses
+// are preferred over IPv6 addresses.
+func DiscoverNodeAddresses(ctx context.Context, c client.Reader) ([]string, error) {
+	pods, err := listPods(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	external, internal := &nodeAddresses{}, &nodeAddresses{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		node := &corev1.Node{}
+		if err := c.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to get node %s: %w", pod.Spec.NodeName, err)
+		}
+
+		for _, address := range node.Status.Addresses {
+			switch address.Type {
+			case corev1.NodeExternalIP:
+				external.insert(address.Address)
+			case corev1.NodeInternalIP:
+				internal.insert(address.Address)
+			}
+		}
+	}
+
+	if addresses := external.list(); len(addresses) > 0 {
+		return addresses, nil
+	}
+
+	return internal.list(), nil
+}
+
+// HasReadyNodes returns true, if the shoot cluster has at least one ready
+// node. Traefik cannot be rolled out, before the worker nodes of a new or
+// woken up shoot joined the cluster.
+func HasReadyNodes(ctx context.Context, c client.Reader) (bool, error) {
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return false, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for _, node := range nodes.Items {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// nodeAddresses are the IP addresses of nodes by their IP family.
+type nodeAddresses struct {
+	ipv4, ipv6 sets.Set[string]
+}
+
+// insert adds the given address, if it is a valid IP address.
+func (a *nodeAddresses) insert(address string) {
+	if a.ipv4 == nil {
+		a.ipv4, a.ipv6 = sets.New[string](), sets.New[string]()
+	}
+
+	ip := net.ParseIP(address)
+	switch {
+	case ip == nil:
+	case ip.To4() != nil:
+		a.ipv4.Insert(address)
+	default:
+		a.ipv6.Insert(address)
+	}
+}
+
+// list returns the sorted IPv4 addresses, or the IPv6 addresses, if there are
+// no IPv4 addresses.
+func (a *nodeAddresses) list() []string {
+	if a.ipv4.Len() > 0 {
+		return sets.List(a.ipv4)
+	}
+
+	return sets.List(a.ipv6)
+}
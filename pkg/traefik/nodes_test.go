@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func traefikPod(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: Namespace,
+			Name:      name,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":     "traefik",
+				"app.kubernetes.io/instance": "traefik",
+			},
+		},
+		Spec:   corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func node(name string, addresses ...corev1.NodeAddress) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Addresses: addresses},
+	}
+}
+
+func TestDiscoverNodeAddresses(t *testing.T) {
+	tests := []struct {
+		name     string
+		objects  []client.Object
+		expected []string
+	}{
+		{
+			name: "external IPs of nodes running traefik",
+			objects: []client.Object{
+				traefikPod("traefik-a", "node-a", corev1.PodRunning),
+				traefikPod("traefik-b", "node-b", corev1.PodRunning),
+				traefikPod("traefik-c", "node-c", corev1.PodPending),
+				traefikPod("traefik-d", "", corev1.PodPending),
+				traefikPod("traefik-e", "node-gone", corev1.PodRunning),
+				node("node-a",
+					corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+					corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.20"},
+				),
+				node("node-b",
+					corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.10"},
+					corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "2001:db8::10"},
+				),
+				node("node-c", corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.30"}),
+				node("node-d", corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.40"}),
+			},
+			expected: []string{"203.0.113.10", "203.0.113.20"},
+		},
+		{
+			name: "IPv6 only",
+			objects: []client.Object{
+				traefikPod("traefik-a", "node-a", corev1.PodRunning),
+				node("node-a", corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "2001:db8::10"}),
+			},
+			expected: []string{"2001:db8::10"},
+		},
+		{
+			name: "internal IPs without external IPs",
+			objects: []client.Object{
+				traefikPod("traefik-a", "node-a", corev1.PodRunning),
+				traefikPod("traefik-b", "node-b", corev1.PodRunning),
+				node("node-a",
+					corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.2"},
+					corev1.NodeAddress{Type: corev1.NodeHostName, Address: "node-a"},
+				),
+				node("node-b", corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}),
+			},
+			expected: []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name: "external IPs preferred over internal IPs",
+			objects: []client.Object{
+				traefikPod("traefik-a", "node-a", corev1.PodRunning),
+				traefikPod("traefik-b", "node-b", corev1.PodRunning),
+				node("node-a", corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}),
+				node("node-b",
+					corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.2"},
+					corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.10"},
+				),
+			},
+			expected: []string{"203.0.113.10"},
+		},
+		{
+			name: "no node addresses",
+			objects: []client.Object{
+				traefikPod("traefik-a", "node-a", corev1.PodRunning),
+				node("node-a", corev1.NodeAddress{Type: corev1.NodeHostName, Address: "node-a"}),
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(tt.objects...).Build()
+
+			addresses, err := DiscoverNodeAddresses(context.Background(), c)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(addresses, tt.expected) {
+				t.Errorf("expected addresses %v, got %v", tt.expected, addresses)
+			}
+		})
+	}
+}
+
+func TestHasReadyNodes(t *testing.T) {
+	readyNode := func(name string, status corev1.ConditionStatus) *corev1.Node {
+		n := node(name)
+		n.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}
+
+		return n
+	}
+
+	tests := []struct {
+		name     string
+		nodes    []client.Object
+		expected bool
+	}{
+		{
+			name: "no nodes",
+		},
+		{
+			name:  "no ready nodes",
+			nodes: []client.Object{readyNode("node-a", corev1.ConditionFalse), node("node-b")},
+		},
+		{
+			name:     "ready node",
+			nodes:    []client.Object{readyNode("node-a", corev1.ConditionFalse), readyNode("node-b", corev1.ConditionTrue)},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(tt.nodes...).Build()
+
+			ready, err := HasReadyNodes(context.Background(), c)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ready != tt.expected {
+				t.Errorf("expected ready nodes %t, got %t", tt.expected, ready)
+			}
+		})
+	}
+}
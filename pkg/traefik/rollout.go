@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultRolloutTimeout is the default progress deadline of the Traefik
+	// Deployment in the shoot cluster, after which its rollout fails.
+	DefaultRolloutTimeout = 3 * time.Minute
+	// maxPodFailureReasons is the maximum number of pod failure reasons,
+	// which are reported for a failed rollout.
+	maxPodFailureReasons = 5
+	// maxTerminationMessageLength is the maximum length of the termination
+	// message of a container in a pod failure reason.
+	maxTerminationMessageLength = 256
+)
+
+var (
+	// ErrRolloutProgressing is returned by [Deployer.CheckRollout], while
+	// Traefik is being rolled out.
+	ErrRolloutProgressing = errors.New("traefik rollout is progressing")
+	// ErrRolloutFailed is returned by [Deployer.CheckRollout], if the
+	// rollout of Traefik failed.
+	ErrRolloutFailed = errors.New("traefik rollout failed")
+)
+
+// CheckRollout checks, whether the ManagedResource of Traefik is applied and
+// healthy and the Traefik pods in the shoot cluster are rolled out, without
+// waiting for the rollout.
+//
+// The returned error wraps [ErrRolloutFailed], if the Deployment of Traefik
+// exceeded its progress deadline or a Traefik pod is crashlooping, and
+// contains the failure reasons of the Traefik pods in this case. Otherwise,
+// an error wrapping [ErrRolloutProgressing] is returned, while the rollout
+// is not finished.
+func (d *Deployer) CheckRollout(ctx context.Context, namespace string, shootClient client.Reader) error {
+	rolloutErr := d.checkRollout(ctx, namespace, shootClient)
+	if rolloutErr == nil {
+		d.logger.Info("traefik is rolled out", "namespace", namespace)
+
+		return nil
+	}
+
+	failure, err := d.rolloutFailure(ctx, shootClient)
+	if err != nil {
+		return err
+	}
+	if failure == "" {
+		return fmt.Errorf("%w: %w", ErrRolloutProgressing, rolloutErr)
+	}
+
+	reasons, err := PodFailureReasons(ctx, shootClient)
+	if err != nil {
+		d.logger.Error(err, "failed to determine the failure reasons of the traefik pods")
+	}
+	if len(reasons) == 0 {
+		return fmt.Errorf("%w: %s", ErrRolloutFailed, failure)
+	}
+
+	return fmt.Errorf("%w: %s, pod failures: %s", ErrRolloutFailed, failure, strings.Join(reasons, "; "))
+}
+
+// rolloutFailure returns the reason, why the rollout of Traefik failed, or an
+// empty string, if the rollout did not fail (yet). A rollout fails, if the
+// Deployment of Traefik exceeded its progress deadline or a Traefik pod is
+// crashlooping.
+func (d *Deployer) rolloutFailure(ctx context.Context, shootClient client.Reader) (string, error) {
+	if !d.config.DaemonSetMode() {
+		deployment := &appsv1.Deployment{}
+		if err := shootClient.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: DeploymentName}, deployment); client.IgnoreNotFound(err) != nil {
+			return "", fmt.Errorf("failed to get traefik deployment: %w", err)
+		}
+		for _, condition := range deployment.Status.Conditions {
+			if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+				return fmt.Sprintf("traefik deployment exceeded its progress deadline: %s", condition.Message), nil
+			}
+		}
+	}
+
+	pods, err := listPods(ctx, shootClient)
+	if err != nil {
+		return "", err
+	}
+	slices.SortFunc(pods, func(a, b corev1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, pod := range pods {
+		for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+			if waiting := status.State.Waiting; waiting != nil && waiting.Reason == "CrashLoopBackOff" {
+				return fmt.Sprintf("traefik pod %s is crashlooping", pod.Name), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// checkRollout returns an error, if the ManagedResource of Traefik is not
+// applied and healthy or the Deployment or DaemonSet of Traefik is not rolled
+// out yet.
+func (d *Deployer) checkRollout(ctx context.Context, namespace string, shootClient client.Reader) error {
+	mr := &resourcesv1alpha1.ManagedResource{}
+	if err := d.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ManagedResourceName}, mr); err != nil {
+		return fmt.Errorf("failed to get managed resource: %w", err)
+	}
+	if err := health.CheckManagedResource(mr); err != nil {
+		return err
+	}
+
+	if d.config.DaemonSetMode() {
+		daemonSet := &appsv1.DaemonSet{}
+		if err := shootClient.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: DeploymentName}, daemonSet); err != nil {
+			return fmt.Errorf("failed to get traefik daemonset: %w", err)
+		}
+		if progressing, reason := health.IsDaemonSetProgressing(daemonSet); progressing {
+			return errors.New(reason)
+		}
+
+		return health.CheckDaemonSet(daemonSet)
+	}
+
+	deployment := &appsv1.Deployment{}
+	deployment.Namespace, deployment.Name = Namespace, DeploymentName
+	if _, err := health.IsDeploymentUpdated(shootClient, deployment)(ctx); err != nil {
+		return fmt.Errorf("traefik deployment is not rolled out: %w", err)
+	}
+
+	return nil
+}
+
+// PodFailureReasons returns the reasons, why Traefik pods in the shoot
+// cluster are not ready, e.g. unschedulable pods, failing image pulls or
+// crashing containers. At most [maxPodFailureReasons] reasons are returned.
+func PodFailureReasons(ctx context.Context, c client.Reader) ([]string, error) {
+	pods, err := listPods(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(pods, func(a, b corev1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	reasons := []string{}
+	for _, pod := range pods {
+		if podReady(pod) {
+			continue
+		}
+		reasons = append(reasons, podFailureReasons(pod)...)
+	}
+
+	if len(reasons) > maxPodFailureReasons {
+		more := len(reasons) - maxPodFailureReasons
+		reasons = append(reasons[:maxPodFailureReasons], fmt.Sprintf("and %d more", more))
+	}
+
+	return reasons, nil
+}
+
+// podFailureReasons returns the reasons, why the given pod is not ready.
+func podFailureReasons(pod corev1.Pod) []string {
+	reasons := []string{}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+			reasons = append(reasons, fmt.Sprintf("pod %s is not scheduled (%s): %s", pod.Name, condition.Reason, condition.Message))
+		}
+	}
+
+	for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "ContainerCreating" && waiting.Reason != "PodInitializing" {
+			reason := fmt.Sprintf("container %s of pod %s is waiting (%s)", status.Name, pod.Name, waiting.Reason)
+			if waiting.Message != "" {
+				reason += ": " + waiting.Message
+			}
+			reasons = append(reasons, reason)
+		}
+
+		terminated := status.State.Terminated
+		if terminated == nil {
+			terminated = status.LastTerminationState.Terminated
+		}
+		if terminated != nil && terminated.ExitCode != 0 {
+			reason := fmt.Sprintf("container %s of pod %s terminated with exit code %d (%s)", status.Name, pod.Name, terminated.ExitCode, terminated.Reason)
+			if message := terminationMessage(terminated.Message); message != "" {
+				reason += ": " + message
+			}
+			reasons = append(reasons, reason)
+		}
+	}
+
+	return reasons
+}
+
+// terminationMessage returns the last non-empty line of the termination
+// message of a container, which contains the error of Traefik, if the message
+// is taken from its logs.
+func terminationMessage(message string) string {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	if len(last) > maxTerminationMessageLength {
+		last = last[:maxTerminationMessageLength] + "..."
+	}
+
+	return last
+}
+
+// podReady returns true, if the Ready condition of the given pod is true.
+func podReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// listPods returns the Traefik pods in the shoot cluster.
+func listPods(ctx context.Context, c client.Reader) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(Namespace), client.MatchingLabels{
+		"app.kubernetes.io/name":     "traefik",
+		"app.kubernetes.io/instance": "traefik",
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list traefik pods: %w", err)
+	}
+
+	return pods.Items, nil
+}
@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func crashingPod(name string) *corev1.Pod {
+	pod := traefikPod(name, "node-a", corev1.PodRunning)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: "traefik",
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{
+					Reason:  "CrashLoopBackOff",
+					Message: "back-off 10s restarting failed container",
+				},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Reason:   "Error",
+					Message:  "INF Traefik version 3.6.10\nERR command traefik error: failed to decode configuration from flags: field not found, node: foo\n",
+				},
+			},
+		},
+	}
+
+	return pod
+}
+
+func TestPodFailureReasons(t *testing.T) {
+	ready := traefikPod("traefik-ready", "node-a", corev1.PodRunning)
+	ready.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	ready.Status.ContainerStatuses = crashingPod("traefik-ready").Status.ContainerStatuses
+
+	pending := traefikPod("traefik-pending", "", corev1.PodPending)
+	pending.Status.Conditions = []corev1.PodCondition{{
+		Type:    corev1.PodScheduled,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Unschedulable",
+		Message: "0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector.",
+	}}
+
+	pulling := traefikPod("traefik-pulling", "node-a", corev1.PodPending)
+	pulling.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name: "traefik",
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: `Back-off pulling image "traefik:v0"`},
+		},
+	}}
+
+	creating := traefikPod("traefik-creating", "node-a", corev1.PodPending)
+	creating.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name: "traefik",
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"},
+		},
+	}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(clientgoscheme.Scheme).
+		WithObjects(ready, pending, pulling, creating, crashingPod("traefik-crashing")).
+		Build()
+
+	reasons, err := PodFailureReasons(context.Background(), c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"container traefik of pod traefik-crashing is waiting (CrashLoopBackOff): back-off 10s restarting failed container",
+		"container traefik of pod traefik-crashing terminated with exit code 1 (Error): ERR command traefik error: failed to decode configuration from flags: field not found, node: foo",
+		"pod traefik-pending is not scheduled (Unschedulable): 0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector.",
+		`container traefik of pod traefik-pulling is waiting (ImagePullBackOff): Back-off pulling image "traefik:v0"`,
+	}
+	if !slices.Equal(reasons, expected) {
+		t.Errorf("unexpected pod failure reasons:\n%s", strings.Join(reasons, "\n"))
+	}
+}
+
+func TestPodFailureReasons_Limit(t *testing.T) {
+	objects := []client.Object{}
+	for _, name := range []string{"traefik-a", "traefik-b", "traefik-c", "traefik-d"} {
+		objects = append(objects, crashingPod(name))
+	}
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objects...).Build()
+
+	reasons, err := PodFailureReasons(context.Background(), c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(reasons) != maxPodFailureReasons+1 || reasons[maxPodFailureReasons] != "and 3 more" {
+		t.Errorf("expected %d pod failure reasons and a remainder, got: %v", maxPodFailureReasons, reasons)
+	}
+}
+
+func TestCheckRollout(t *testing.T) {
+	const namespace = "shoot--foo--bar"
+
+	seedScheme := runtime.NewScheme()
+	_ = resourcesv1alpha1.AddToScheme(seedScheme)
+
+	managedResource := func(healthy gardencorev1beta1.ConditionStatus) *resourcesv1alpha1.ManagedResource {
+		return &resourcesv1alpha1.ManagedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: ManagedResourceName, Namespace: namespace},
+			Status: resourcesv1alpha1.ManagedResourceStatus{
+				Conditions: []gardencorev1beta1.Condition{
+					{Type: resourcesv1alpha1.ResourcesApplied, Status: gardencorev1beta1.ConditionTrue},
+					{Type: resourcesv1alpha1.ResourcesHealthy, Status: healthy, Message: "Deployment kube-system/traefik is unhealthy"},
+				},
+			},
+		}
+	}
+
+	deployment := func(progressing appsv1.DeploymentCondition) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: DeploymentName, Namespace: Namespace},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: new(int32(1)),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+					"app.kubernetes.io/name":     "traefik",
+					"app.kubernetes.io/instance": "traefik",
+				}},
+			},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+					progressing,
+				},
+			},
+		}
+	}
+	progressing := appsv1.DeploymentCondition{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "ReplicaSetUpdated"}
+	rolledOut := appsv1.DeploymentCondition{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"}
+	deadlineExceeded := appsv1.DeploymentCondition{
+		Type:    appsv1.DeploymentProgressing,
+		Status:  corev1.ConditionFalse,
+		Reason:  "ProgressDeadlineExceeded",
+		Message: `ReplicaSet "traefik-abc" has timed out progressing.`,
+	}
+
+	pending := traefikPod("traefik-a", "", corev1.PodPending)
+	pending.Status.Conditions = []corev1.PodCondition{{
+		Type:    corev1.PodScheduled,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Unschedulable",
+		Message: "0/3 nodes are available",
+	}}
+
+	tests := []struct {
+		name            string
+		managedResource *resourcesv1alpha1.ManagedResource
+		deployment      *appsv1.Deployment
+		pod             *corev1.Pod
+		expectedErr     error
+		expectedMessage string
+	}{
+		{
+			name:            "rolled out",
+			managedResource: managedResource(gardencorev1beta1.ConditionTrue),
+			deployment:      deployment(rolledOut),
+			pod:             traefikPod("traefik-a", "node-a", corev1.PodRunning),
+		},
+		{
+			name:        "managed resource not applied yet",
+			pod:         traefikPod("traefik-a", "node-a", corev1.PodPending),
+			expectedErr: ErrRolloutProgressing,
+		},
+		{
+			name:            "pending pod",
+			managedResource: managedResource(gardencorev1beta1.ConditionFalse),
+			deployment:      deployment(progressing),
+			pod:             pending,
+			expectedErr:     ErrRolloutProgressing,
+		},
+		{
+			name:            "crashing traefik",
+			managedResource: managedResource(gardencorev1beta1.ConditionFalse),
+			deployment:      deployment(progressing),
+			pod:             crashingPod("traefik-a"),
+			expectedErr:     ErrRolloutFailed,
+			expectedMessage: "field not found, node: foo",
+		},
+		{
+			name:            "progress deadline exceeded",
+			managedResource: managedResource(gardencorev1beta1.ConditionFalse),
+			deployment:      deployment(deadlineExceeded),
+			pod:             pending,
+			expectedErr:     ErrRolloutFailed,
+			expectedMessage: "exceeded its progress deadline: ReplicaSet \"traefik-abc\" has timed out progressing., pod failures: pod traefik-a is not scheduled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedObjects := []client.Object{}
+			if tt.managedResource != nil {
+				seedObjects = append(seedObjects, tt.managedResource)
+			}
+			shootObjects := []client.Object{tt.pod}
+			if tt.deployment != nil {
+				shootObjects = append(shootObjects, tt.deployment)
+			}
+			seedClient := fake.NewClientBuilder().WithScheme(seedScheme).WithObjects(seedObjects...).Build()
+			shootClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(shootObjects...).Build()
+			deployer := NewDeployer(seedClient, logr.Discard(), DefaultConfig(), nil)
+
+			err := deployer.CheckRollout(context.Background(), namespace, shootClient)
+			if tt.expectedErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got: %v", tt.expectedErr, err)
+			}
+			if !strings.Contains(err.Error(), tt.expectedMessage) {
+				t.Errorf("expected error containing %q, got: %v", tt.expectedMessage, err)
+			}
+		})
+	}
+}
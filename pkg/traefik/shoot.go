@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	v1beta1helper "github.com/gardener/gardener/pkg/api/core/v1beta1/helper"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+// ApplyShoot completes the configuration with the settings, which are derived
+// from the given shoot:
+//
+//   - the zones of the worker pools of the shoot, see [WorkerZones]
+//   - the host of the secure dashboard, which is disabled, if the shoot has no
+//     DNS domain, because it cannot be published in that case
+//   - no replicas, if the shoot is hibernated, unless Traefik runs as
+//     DaemonSet, whose pods are removed together with the nodes of the shoot
+//
+// The BasicAuth credentials of the secure dashboard are not set, see
+// [Config.DashboardCredentialsRequired].
+func (c *Config) ApplyShoot(shoot *gardencorev1beta1.Shoot) {
+	c.Zones = WorkerZones(shoot)
+
+	if c.secureDashboardEnabled() {
+		if domain := ShootDomain(shoot); domain != "" {
+			c.SecureDashboard.Host = DashboardHost(domain)
+		} else {
+			c.Dashboard = false
+			c.SecureDashboard = nil
+		}
+	}
+
+	if v1beta1helper.HibernationIsEnabled(shoot) && !c.DaemonSetMode() {
+		c.Replicas = 0
+	}
+}
+
+// DashboardCredentialsRequired returns true, if the secure dashboard is
+// published with BasicAuth authentication, which requires the htpasswd
+// entries of its credentials.
+func (c Config) DashboardCredentialsRequired() bool {
+	return c.secureDashboardEnabled() && c.SecureDashboard.Auth != config.DashboardAuthOIDC
+}
+
+// DNSRecordEnabled returns true, if the ingress DNS record is deployed for the
+// given shoot. The ingress DNS record requires a DNS domain of the shoot, and
+// it is only kept for a hibernated shoot with the Keep DNS record policy.
+func (c Config) DNSRecordEnabled(shoot *gardencorev1beta1.Shoot) bool {
+	if ShootDomain(shoot) == "" {
+		return false
+	}
+
+	return !v1beta1helper.HibernationIsEnabled(shoot) || c.HibernationDNSRecordPolicy == config.DNSRecordPolicyKeep
+}
+
+// WorkerZones returns the sorted zones of all worker pools of the given shoot.
+func WorkerZones(shoot *gardencorev1beta1.Shoot) []string {
+	zones := sets.New[string]()
+	for _, worker := range shoot.Spec.Provider.Workers {
+		zones.Insert(worker.Zones...)
+	}
+
+	return sets.List(zones)
+}
+
+// ShootDomain returns the DNS domain of the given shoot, or an empty string, if
+// the shoot has no DNS domain.
+func ShootDomain(shoot *gardencorev1beta1.Shoot) string {
+	if shoot.Spec.DNS == nil || shoot.Spec.DNS.Domain == nil {
+		return ""
+	}
+
+	return *shoot.Spec.DNS.Domain
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"slices"
+	"testing"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	"github.com/gardener/gardener-extension-shoot-traefik/pkg/apis/config"
+)
+
+func testShoot(domain string, hibernated bool, zones ...[]string) *gardencorev1beta1.Shoot {
+	shoot := &gardencorev1beta1.Shoot{}
+	if domain != "" {
+		shoot.Spec.DNS = &gardencorev1beta1.DNS{Domain: new(domain)}
+	}
+	if hibernated {
+		shoot.Spec.Hibernation = &gardencorev1beta1.Hibernation{Enabled: new(true)}
+	}
+	for _, workerZones := range zones {
+		shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, gardencorev1beta1.Worker{Zones: workerZones})
+	}
+
+	return shoot
+}
+
+func TestConfig_ApplyShoot(t *testing.T) {
+	secureDashboard := func(auth config.DashboardAuthType) Config {
+		cfg := DefaultConfig()
+		cfg.Dashboard = true
+		cfg.SecureDashboard = &SecureDashboard{Auth: auth}
+
+		return cfg
+	}
+	daemonSet := DefaultConfig()
+	daemonSet.DeploymentMode = config.DeploymentModeDaemonSet
+
+	tests := []struct {
+		name                      string
+		config                    Config
+		shoot                     *gardencorev1beta1.Shoot
+		expectZones               []string
+		expectReplicas            int32
+		expectDashboardHost       string
+		expectDashboardDisabled   bool
+		expectCredentialsRequired bool
+		expectDNSRecordEnabled    bool
+	}{
+		{
+			name:                   "zones of all worker pools",
+			config:                 DefaultConfig(),
+			shoot:                  testShoot("example.com", false, []string{"zone-b", "zone-a"}, []string{"zone-a", "zone-c"}),
+			expectZones:            []string{"zone-a", "zone-b", "zone-c"},
+			expectReplicas:         DefaultConfig().Replicas,
+			expectDNSRecordEnabled: true,
+		},
+		{
+			name:                      "host of the BasicAuth dashboard",
+			config:                    secureDashboard(config.DashboardAuthBasicAuth),
+			shoot:                     testShoot("example.com", false),
+			expectZones:               []string{},
+			expectReplicas:            DefaultConfig().Replicas,
+			expectDashboardHost:       "dashboard.ingress.example.com",
+			expectCredentialsRequired: true,
+			expectDNSRecordEnabled:    true,
+		},
+		{
+			name:                   "host of the OIDC dashboard",
+			config:                 secureDashboard(config.DashboardAuthOIDC),
+			shoot:                  testShoot("example.com", false),
+			expectZones:            []string{},
+			expectReplicas:         DefaultConfig().Replicas,
+			expectDashboardHost:    "dashboard.ingress.example.com",
+			expectDNSRecordEnabled: true,
+		},
+		{
+			name:                    "dashboard disabled without DNS domain",
+			config:                  secureDashboard(config.DashboardAuthBasicAuth),
+			shoot:                   testShoot("", false),
+			expectZones:             []string{},
+			expectReplicas:          DefaultConfig().Replicas,
+			expectDashboardDisabled: true,
+		},
+		{
+			name:           "no replicas for hibernated shoot",
+			config:         DefaultConfig(),
+			shoot:          testShoot("example.com", true),
+			expectZones:    []string{},
+			expectReplicas: 0,
+		},
+		{
+			name:           "replicas of DaemonSet kept for hibernated shoot",
+			config:         daemonSet,
+			shoot:          testShoot("example.com", true),
+			expectZones:    []string{},
+			expectReplicas: daemonSet.Replicas,
+		},
+		{
+			name: "DNS record kept for hibernated shoot",
+			config: func() Config {
+				cfg := DefaultConfig()
+				cfg.HibernationDNSRecordPolicy = config.DNSRecordPolicyKeep
+
+				return cfg
+			}(),
+			shoot:                  testShoot("example.com", true),
+			expectZones:            []string{},
+			expectReplicas:         0,
+			expectDNSRecordEnabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.config
+			if cfg.SecureDashboard != nil {
+				secure := *cfg.SecureDashboard
+				cfg.SecureDashboard = &secure
+			}
+			cfg.ApplyShoot(tt.shoot)
+
+			if !slices.Equal(cfg.Zones, tt.expectZones) {
+				t.Errorf("expected zones %v, got %v", tt.expectZones, cfg.Zones)
+			}
+			if cfg.Replicas != tt.expectReplicas {
+				t.Errorf("expected %d replicas, got %d", tt.expectReplicas, cfg.Replicas)
+			}
+			if tt.expectDashboardDisabled {
+				if cfg.Dashboard || cfg.SecureDashboard != nil {
+					t.Errorf("expected the dashboard to be disabled")
+				}
+			} else if tt.expectDashboardHost != "" && cfg.SecureDashboard.Host != tt.expectDashboardHost {
+				t.Errorf("expected dashboard host %q, got %q", tt.expectDashboardHost, cfg.SecureDashboard.Host)
+			}
+			if got := cfg.DashboardCredentialsRequired(); got != tt.expectCredentialsRequired {
+				t.Errorf("expected dashboard credentials required %v, got %v", tt.expectCredentialsRequired, got)
+			}
+			if got := cfg.DNSRecordEnabled(tt.shoot); got != tt.expectDNSRecordEnabled {
+				t.Errorf("expected DNS record enabled %v, got %v", tt.expectDNSRecordEnabled, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+)
+
+// ErrUnsupportedVersion is returned when a Traefik version is requested, which
+// is not offered by the image vector.
+var ErrUnsupportedVersion = errors.New("unsupported traefik version")
+
+// SupportedVersions returns the Traefik versions offered by the given image
+// vector in the order of the image vector. The first version is the default
+// version, which is deployed when no version is requested.
+func SupportedVersions(iv imagevector.ImageVector) []string {
+	versions := make([]string, 0)
+	for _, src := range iv {
+		if src.Name != ImageName {
+			continue
+		}
+
+		img := src.ToImage(nil)
+		if img.Version == nil || slices.Contains(versions, *img.Version) {
+			continue
+		}
+		versions = append(versions, *img.Version)
+	}
+
+	return versions
+}
+
+// FilterVersions returns a copy of the given image vector, which only offers
+// the given Traefik versions. Images other than Traefik are kept as is. An
+// error is returned, if one of the versions is not part of the image vector.
+//
+// The order of the image vector is kept, i.e. the default version is the
+// first offered version of the image vector, which is part of the given
+// versions.
+func FilterVersions(iv imagevector.ImageVector, versions []string) (imagevector.ImageVector, error) {
+	supported := SupportedVersions(iv)
+	wanted := make([]string, 0, len(versions))
+	for _, version := range versions {
+		version = normalizeVersion(version)
+		if !slices.Contains(supported, version) {
+			return nil, fmt.Errorf("%w: %s is not part of the image vector, supported versions are %s", ErrUnsupportedVersion, version, strings.Join(supported, ", "))
+		}
+		wanted = append(wanted, version)
+	}
+
+	result := make(imagevector.ImageVector, 0, len(iv))
+	for _, src := range iv {
+		if src.Name == ImageName {
+			img := src.ToImage(nil)
+			if img.Version == nil || !slices.Contains(wanted, *img.Version) {
+				continue
+			}
+		}
+		result = append(result, src)
+	}
+
+	return result, nil
+}
+
+// FindImage returns the Traefik image for the given version from the image
+// vector. The image vector entries are matched against their targetVersion
+// constraint. If version is empty, the default image is returned.
+func FindImage(iv imagevector.ImageVector, version string) (*imagevector.Image, error) {
+	if version == "" {
+		return iv.FindImage(ImageName)
+	}
+
+	version = normalizeVersion(version)
+	img, err := iv.FindImage(ImageName, imagevector.TargetVersion(strings.TrimPrefix(version, "v")))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrUnsupportedVersion, version, err)
+	}
+
+	// Entries without a targetVersion constraint match any version, so make
+	// sure that we actually found the requested one.
+	if img.Version == nil || *img.Version != version {
+		return nil, fmt.Errorf("%w: %s, supported versions are %s", ErrUnsupportedVersion, version, strings.Join(SupportedVersions(iv), ", "))
+	}
+
+	return img, nil
+}
+
+// normalizeVersion returns the given version with a "v" prefix, which is the
+// format used for the tags of the Traefik images.
+func normalizeVersion(version string) string {
+	return "v" + strings.TrimPrefix(strings.TrimSpace(version), "v")
+}
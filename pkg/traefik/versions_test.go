@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traefik
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+
+	extenimagev "github.com/gardener/gardener-extension-shoot-traefik/imagevector"
+)
+
+func testVersionedImageVector() imagevector.ImageVector {
+	return imagevector.ImageVector{
+		{
+			Name:          ImageName,
+			Repository:    new("docker.io/library/traefik"),
+			Tag:           new("v3.6.13"),
+			TargetVersion: new("= 3.6.13"),
+		},
+		{
+			Name:          ImageName,
+			Repository:    new("docker.io/library/traefik"),
+			Tag:           new("v3.6.11"),
+			TargetVersion: new("= 3.6.11"),
+		},
+		{
+			Name:       "other",
+			Repository: new("docker.io/library/other"),
+			Tag:        new("v1.0.0"),
+		},
+	}
+}
+
+func TestSupportedVersions(t *testing.T) {
+	versions := SupportedVersions(testVersionedImageVector())
+
+	expected := []string{"v3.6.13", "v3.6.11"}
+	if !slices.Equal(versions, expected) {
+		t.Errorf("expected versions %v, got %v", expected, versions)
+	}
+}
+
+func TestFindImage(t *testing.T) {
+	tests := []struct {
+		name        string
+		imageVector imagevector.ImageVector
+		version     string
+		expectedTag string
+		expectError bool
+	}{
+		{
+			name:        "default version",
+			imageVector: testVersionedImageVector(),
+			expectedTag: "v3.6.13",
+		},
+		{
+			name:        "pinned version",
+			imageVector: testVersionedImageVector(),
+			version:     "v3.6.11",
+			expectedTag: "v3.6.11",
+		},
+		{
+			name:        "pinned version without prefix",
+			imageVector: testVersionedImageVector(),
+			version:     "3.6.11",
+			expectedTag: "v3.6.11",
+		},
+		{
+			name:        "unknown version",
+			imageVector: testVersionedImageVector(),
+			version:     "v3.5.0",
+			expectError: true,
+		},
+		{
+			name: "unknown version without targetVersion constraint",
+			imageVector: imagevector.ImageVector{
+				{
+					Name:       ImageName,
+					Repository: new("docker.io/library/traefik"),
+					Tag:        new("v3.6.10"),
+				},
+			},
+			version:     "v3.6.11",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := FindImage(tt.imageVector, tt.version)
+			if tt.expectError {
+				if !errors.Is(err, ErrUnsupportedVersion) {
+					t.Errorf("expected ErrUnsupportedVersion, got: %v", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if img.Tag == nil || *img.Tag != tt.expectedTag {
+				t.Errorf("expected tag %q, got %v", tt.expectedTag, img.Tag)
+			}
+		})
+	}
+}
+
+func TestFilterVersions(t *testing.T) {
+	tests := []struct {
+		name        string
+		versions    []string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "keep default version",
+			versions: []string{"v3.6.13"},
+			expected: []string{"v3.6.13"},
+		},
+		{
+			name:     "keep image vector order",
+			versions: []string{"3.6.11", "3.6.13"},
+			expected: []string{"v3.6.13", "v3.6.11"},
+		},
+		{
+			name:        "unknown version",
+			versions:    []string{"v3.5.0"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iv, err := FilterVersions(testVersionedImageVector(), tt.versions)
+			if tt.expectError {
+				if !errors.Is(err, ErrUnsupportedVersion) {
+					t.Errorf("expected ErrUnsupportedVersion, got: %v", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if versions := SupportedVersions(iv); !slices.Equal(versions, tt.expected) {
+				t.Errorf("expected versions %v, got %v", tt.expected, versions)
+			}
+			if _, err := iv.FindImage("other"); err != nil {
+				t.Errorf("expected other images to be kept: %v", err)
+			}
+		})
+	}
+}
+
+// TestImageVector_VersionsResolvable ensures that each Traefik entry of the
+// image vector can be selected via its version, i.e. that the targetVersion
+// constraint matches the tag of the entry.
+func TestImageVector_VersionsResolvable(t *testing.T) {
+	iv := extenimagev.ImageVector()
+
+	for _, version := range SupportedVersions(iv) {
+		img, err := FindImage(iv, version)
+		if err != nil {
+			t.Errorf("traefik version %s cannot be resolved, check the targetVersion constraint in images.yaml: %v", version, err)
+
+			continue
+		}
+		if *img.Version != version {
+			t.Errorf("traefik version %s resolves to image %s", version, img.String())
+		}
+	}
+}
@@ -9,8 +9,11 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	authenticationv1alpha1 "github.com/gardener/gardener/pkg/apis/authentication/v1alpha1"
@@ -81,6 +84,11 @@ var _ = Describe("Traefik Extension E2E", Ordered, func() {
 			shootClient := getShootClient(ctx, shootKubernetesIngress)
 			verifyIngress(ctx, shootClient, "traefik")
 		})
+
+		It("should not drop requests during a rollout of Traefik", func() {
+			shootClient := getShootClient(ctx, shootKubernetesIngress)
+			verifyZeroDowntimeRollout(ctx, shootClient, shootKubernetesIngress)
+		})
 	})
 
 	Context("KubernetesIngressNGINX provider", func() {
@@ -548,6 +556,125 @@ func validateHTTPConnectivity(ctx context.Context, lbAddress string) {
 	}, IngressReadyTimeout, 15*time.Second).Should(Succeed(), "could not reach whoami through traefik ingress")
 }
 
+// verifyZeroDowntimeRollout continuously sends requests through the Traefik
+// ingress, while a change of the TraefikConfig rolls out a new revision of
+// Traefik, and expects none of the requests to fail. It requires the whoami
+// workload and Ingress of [verifyIngress].
+func verifyZeroDowntimeRollout(ctx context.Context, shootClient client.Client, shoot *gardencorev1beta1.Shoot) {
+	lbAddress := waitForTraefikLBAddress(ctx, shootClient)
+	url := fmt.Sprintf("http://%s/", lbAddress)
+
+	By("Sending requests through the Traefik ingress")
+	loadCtx, stopLoad := context.WithCancel(ctx)
+	defer stopLoad()
+
+	var (
+		sent     atomic.Int64
+		mu       sync.Mutex
+		failures []string
+	)
+	done := make(chan struct{})
+	go func() {
+		defer GinkgoRecover()
+		defer close(done)
+
+		httpClient := &http.Client{Timeout: 10 * time.Second}
+		for loadCtx.Err() == nil {
+			if err := sendRequest(loadCtx, httpClient, url); err != nil && loadCtx.Err() == nil {
+				mu.Lock()
+				failures = append(failures, fmt.Sprintf("%s: %v", time.Now().Format(time.RFC3339), err))
+				mu.Unlock()
+			}
+			sent.Add(1)
+			time.Sleep(100 * time.Millisecond)
+		}
+	}()
+
+	By("Changing the log level of Traefik to roll out a new revision")
+	updateTraefikConfig(ctx, shoot, func(spec map[string]any) {
+		spec["logLevel"] = "Debug"
+	})
+
+	By("Waiting for the rollout of Traefik to complete")
+	waitForTraefikRollout(ctx, shootClient, "--log.level=Debug")
+
+	stopLoad()
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	GinkgoWriter.Printf("Sent %d requests during the rollout, %d failed\n", sent.Load(), len(failures))
+	Expect(sent.Load()).To(BeNumerically(">", 0), "no requests were sent during the rollout")
+	Expect(failures).To(BeEmpty(), "requests were dropped during the rollout")
+}
+
+// sendRequest sends a single GET request to the given URL and returns an
+// error, if it fails or does not return HTTP 200.
+func sendRequest(ctx context.Context, httpClient *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// updateTraefikConfig modifies the spec of the TraefikConfig of the given
+// shoot.
+func updateTraefikConfig(ctx context.Context, shoot *gardencorev1beta1.Shoot, modify func(spec map[string]any)) {
+	current := &gardencorev1beta1.Shoot{}
+	Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(shoot), current)).To(Succeed())
+
+	patch := client.MergeFrom(current.DeepCopy())
+	for i, extension := range current.Spec.Extensions {
+		if extension.Type != "shoot-traefik" || extension.ProviderConfig == nil {
+			continue
+		}
+
+		traefikConfig := map[string]any{}
+		Expect(json.Unmarshal(extension.ProviderConfig.Raw, &traefikConfig)).To(Succeed())
+		spec, ok := traefikConfig["spec"].(map[string]any)
+		Expect(ok).To(BeTrue(), "TraefikConfig of shoot %s has no spec", shoot.Name)
+		modify(spec)
+		current.Spec.Extensions[i].ProviderConfig = &runtime.RawExtension{Raw: mustMarshalJSON(traefikConfig)}
+	}
+	Expect(gardenClient.Patch(ctx, current, patch)).To(Succeed(), "failed to update TraefikConfig of shoot %s", shoot.Name)
+}
+
+// waitForTraefikRollout waits until the Traefik Deployment has the given
+// argument, all of its replicas are updated and available, and the pods of
+// the previous revision are gone.
+func waitForTraefikRollout(ctx context.Context, shootClient client.Client, arg string) {
+	Eventually(func(g Gomega) {
+		deployment := &appsv1.Deployment{}
+		g.Expect(shootClient.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "traefik"}, deployment)).To(Succeed())
+		g.Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement(arg), "traefik deployment not yet updated")
+
+		replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+		g.Expect(deployment.Status.ObservedGeneration).To(BeNumerically(">=", deployment.Generation))
+		g.Expect(deployment.Status.UpdatedReplicas).To(Equal(replicas))
+		g.Expect(deployment.Status.AvailableReplicas).To(Equal(replicas))
+
+		pods := &corev1.PodList{}
+		g.Expect(shootClient.List(ctx, pods, client.InNamespace("kube-system"), client.MatchingLabels{
+			"app.kubernetes.io/name":     "traefik",
+			"app.kubernetes.io/instance": "traefik",
+		})).To(Succeed())
+		g.Expect(pods.Items).To(HaveLen(int(replicas)), "pods of the previous revision are still terminating")
+	}, ShootCreationTimeout, PollInterval).Should(Succeed(), "rollout of traefik did not complete in time")
+}
+
 // shootName builds a shoot name from base+suffix, auto-truncating the base so that
 // len(projectName) + len(shootName) <= 21 (Gardener's hard limit).
 // The project name is derived from the project namespace by trimming the "garden-" prefix.